@@ -0,0 +1,110 @@
+package logadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RouteLevel log level of route
+type RouteLevel struct {
+	Path   string // route path pattern, e.g. /internal/*, /static/**
+	Method string // HTTP method, empty for all methods
+	Level  Level
+}
+
+// StatusLevel log level of status code range
+type StatusLevel struct {
+	Min   int // min status code, inclusive
+	Max   int // max status code, inclusive
+	Level Level
+}
+
+// DefaultStatusLevels log 5xx at Error and 4xx at Warn
+var DefaultStatusLevels = []StatusLevel{
+	{Min: http.StatusInternalServerError, Max: 599, Level: ErrorLevel},
+	{Min: http.StatusBadRequest, Max: http.StatusInternalServerError - 1, Level: WarnLevel},
+}
+
+// accessLogLevel get log level of access log by status code, first matched status level is used.
+// If no status level is matched, use ErrorLevel if request has error, otherwise use default level.
+// Request slower than slow threshold is promoted to WarnLevel, returns true if request is slow
+func accessLogLevel(status int, hasError bool, statusLevels []StatusLevel, defaultLevel Level, latency, slowThreshold time.Duration) (Level, bool) {
+	level := defaultLevel
+	matched := false
+	for _, statusLevel := range statusLevels {
+		if status >= statusLevel.Min && status <= statusLevel.Max {
+			level = statusLevel.Level
+			matched = true
+			break
+		}
+	}
+	if !matched && hasError {
+		level = ErrorLevel
+	}
+
+	isSlow := slowThreshold > 0 && latency > slowThreshold
+	if isSlow && level > WarnLevel {
+		level = WarnLevel
+	}
+
+	return level, isSlow
+}
+
+// isExcludedRequest check request is excluded by method or route path pattern
+func isExcludedRequest(method, route string, excludedMethods, excludedPaths []string) bool {
+	for _, excludedMethod := range excludedMethods {
+		if strings.EqualFold(excludedMethod, method) {
+			return true
+		}
+	}
+
+	return matchAnyRoute(excludedPaths, route)
+}
+
+// routeLevel get log level of route, first matched route level is used, default InfoLevel
+func routeLevel(method, route string, routeLevels []RouteLevel) Level {
+	for _, routeLevel := range routeLevels {
+		if routeLevel.Method != "" && !strings.EqualFold(routeLevel.Method, method) {
+			continue
+		}
+		if matchRoute(routeLevel.Path, route) {
+			return routeLevel.Level
+		}
+	}
+
+	return InfoLevel
+}
+
+// accessLogFields returns fields of access log
+func accessLogFields(req *http.Request, ip string, status int, resSize int64, latency time.Duration, err error) map[string]interface{} {
+	reqSizeStr := req.Header.Get("Content-Length")
+	if reqSizeStr == "" {
+		reqSizeStr = "0"
+	}
+	reqSize, _ := strconv.ParseInt(reqSizeStr, 10, 64)
+
+	fields := map[string]interface{}{
+		"ip":         ip,
+		"user_agent": req.UserAgent(),
+		"host":       req.Host,
+		"method":     req.Method,
+		"url":        req.URL.Path,
+		"uri":        req.RequestURI,
+		"status":     status,
+		"byte_in":    reqSize,
+		"byte_out":   resSize,
+		"latency":    latency.String(),
+		"latency_ms": latency.Milliseconds(),
+		"referer":    req.Referer(),
+		"type":       LogTypeAPI,
+	}
+	if err != nil {
+		b, _ := json.Marshal(err.Error())
+		fields["error"] = string(b[1 : len(b)-1])
+	}
+
+	return fields
+}
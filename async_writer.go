@@ -0,0 +1,253 @@
+package logadapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultAsyncBufferSize     = 1024
+	defaultAsyncReportInterval = time.Minute
+)
+
+// levelWriter write formatted log entry with its level
+type levelWriter interface {
+	WriteLevel(level Level, p []byte) (int, error)
+}
+
+// outputFormat format log entry and write it to output with level, logrus writes nothing after that
+type outputFormat struct {
+	formatter log.Formatter
+	output    levelWriter
+}
+
+func (of outputFormat) Format(entry *log.Entry) ([]byte, error) {
+	b, err := of.formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	_, err = of.output.WriteLevel(Level(entry.Level), b)
+
+	return nil, err
+}
+
+type asyncRecord struct {
+	level Level
+	data  []byte
+}
+
+// asyncWriter write log entries to output in background with bounded ring buffer
+type asyncWriter struct {
+	output    io.Writer
+	policy    OverflowPolicy
+	dropLevel Level
+	report    func(dropped uint64)
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     chan struct{} // closed when buffer is empty and no entry is being written
+	buffer   []asyncRecord
+	head     int
+	size     int
+	busy     bool
+	closed   bool
+
+	dropped      uint64 // dropped entries since last report
+	totalDropped uint64
+	done         chan struct{}
+	stopReport   chan struct{}
+}
+
+func newAsyncWriter(output io.Writer, config *AsyncConfig, report func(dropped uint64)) *asyncWriter {
+	bufferSize := config.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultAsyncBufferSize
+	}
+	reportInterval := config.ReportInterval
+	if reportInterval <= 0 {
+		reportInterval = defaultAsyncReportInterval
+	}
+
+	w := &asyncWriter{
+		output:     output,
+		policy:     config.OverflowPolicy,
+		dropLevel:  config.DropLevel,
+		report:     report,
+		buffer:     make([]asyncRecord, bufferSize),
+		idle:       make(chan struct{}),
+		done:       make(chan struct{}),
+		stopReport: make(chan struct{}),
+	}
+	w.notEmpty = sync.NewCond(&w.mu)
+	w.notFull = sync.NewCond(&w.mu)
+	close(w.idle)
+
+	go w.run()
+	go w.runReport(reportInterval)
+
+	return w
+}
+
+// Write write log entry with unknown level, it's never dropped by DropBelowLevel policy
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	return w.WriteLevel(PanicLevel, p)
+}
+
+// WriteLevel push log entry to buffer, apply overflow policy if buffer is full
+func (w *asyncWriter) WriteLevel(level Level, p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// logrus reuses buffer of formatted entry
+	data := append([]byte(nil), p...)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for !w.closed && w.size == len(w.buffer) {
+		switch {
+		case w.policy == OverflowDropNewest,
+			w.policy == OverflowDropBelowLevel && level > w.dropLevel:
+			w.drop()
+			return len(p), nil
+
+		case w.policy == OverflowDropOldest:
+			w.pop()
+			w.drop()
+
+		default:
+			w.notFull.Wait()
+		}
+	}
+
+	if w.closed {
+		// background writer is stopped, write log entry directly
+		return w.output.Write(data)
+	}
+
+	if w.size == 0 && !w.busy {
+		w.idle = make(chan struct{})
+	}
+	w.push(asyncRecord{level: level, data: data})
+	w.notEmpty.Signal()
+
+	return len(p), nil
+}
+
+// Flush wait until all buffered log entries are written or context is done
+func (w *asyncWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	idle := w.idle
+	w.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close write all buffered log entries and stop background writer
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.notEmpty.Broadcast()
+	w.notFull.Broadcast()
+	w.mu.Unlock()
+
+	<-w.done
+	close(w.stopReport)
+	w.reportDropped()
+
+	return nil
+}
+
+// DroppedEntries returns number of dropped entries
+func (w *asyncWriter) DroppedEntries() uint64 {
+	return atomic.LoadUint64(&w.totalDropped)
+}
+
+func (w *asyncWriter) run() {
+	defer close(w.done)
+	for {
+		w.mu.Lock()
+		for w.size == 0 && !w.closed {
+			w.notEmpty.Wait()
+		}
+		if w.size == 0 {
+			w.mu.Unlock()
+			return
+		}
+		record := w.pop()
+		w.busy = true
+		w.notFull.Signal()
+		w.mu.Unlock()
+
+		if _, err := w.output.Write(record.data); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+		}
+
+		w.mu.Lock()
+		w.busy = false
+		if w.size == 0 {
+			close(w.idle)
+		}
+		w.mu.Unlock()
+	}
+}
+
+func (w *asyncWriter) runReport(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.reportDropped()
+		case <-w.stopReport:
+			return
+		}
+	}
+}
+
+func (w *asyncWriter) reportDropped() {
+	dropped := atomic.SwapUint64(&w.dropped, 0)
+	if dropped > 0 && w.report != nil {
+		w.report(dropped)
+	}
+}
+
+func (w *asyncWriter) drop() {
+	atomic.AddUint64(&w.dropped, 1)
+	atomic.AddUint64(&w.totalDropped, 1)
+}
+
+func (w *asyncWriter) push(record asyncRecord) {
+	w.buffer[(w.head+w.size)%len(w.buffer)] = record
+	w.size++
+}
+
+func (w *asyncWriter) pop() asyncRecord {
+	record := w.buffer[w.head]
+	w.buffer[w.head] = asyncRecord{}
+	w.head = (w.head + 1) % len(w.buffer)
+	w.size--
+
+	return record
+}
@@ -0,0 +1,152 @@
+package logadapter
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingWriter blocks writes until release is closed
+type blockingWriter struct {
+	started chan struct{}
+	release chan struct{}
+	mu      sync.Mutex
+	entries []string
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	select {
+	case w.started <- struct{}{}:
+	default:
+	}
+	<-w.release
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.entries = append(w.entries, string(p))
+
+	return len(p), nil
+}
+
+func (w *blockingWriter) written() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return strings.Join(w.entries, "")
+}
+
+func TestAsyncWriterOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    OverflowPolicy
+		level     Level
+		isBlocked bool
+		want      string
+		dropped   uint64
+	}{
+		{name: "block", policy: OverflowBlock, level: InfoLevel, isBlocked: true, want: "abcd"},
+		{name: "drop newest", policy: OverflowDropNewest, level: InfoLevel, want: "abc", dropped: 1},
+		{name: "drop oldest", policy: OverflowDropOldest, level: InfoLevel, want: "acd", dropped: 1},
+		{name: "drop below level", policy: OverflowDropBelowLevel, level: InfoLevel, want: "abc", dropped: 1},
+		{name: "block at drop level", policy: OverflowDropBelowLevel, level: ErrorLevel, isBlocked: true, want: "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := newBlockingWriter()
+			var reported uint64
+			config := &AsyncConfig{BufferSize: 2, OverflowPolicy: tt.policy, DropLevel: WarnLevel}
+			w := newAsyncWriter(output, config, func(dropped uint64) { reported += dropped })
+
+			// a is being written, b and c fill buffer
+			w.WriteLevel(InfoLevel, []byte("a"))
+			<-output.started
+			w.WriteLevel(InfoLevel, []byte("b"))
+			w.WriteLevel(InfoLevel, []byte("c"))
+
+			written := make(chan struct{})
+			go func() {
+				w.WriteLevel(tt.level, []byte("d"))
+				close(written)
+			}()
+			select {
+			case <-written:
+				if tt.isBlocked {
+					t.Error("write is not blocked when buffer is full")
+				}
+			case <-time.After(50 * time.Millisecond):
+				if !tt.isBlocked {
+					t.Error("write is blocked when buffer is full")
+				}
+			}
+
+			close(output.release)
+			<-written
+			// buffered entries are written before Close returns
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := output.written(); got != tt.want {
+				t.Errorf("written %q, want %q", got, tt.want)
+			}
+			if got := w.DroppedEntries(); got != tt.dropped {
+				t.Errorf("DroppedEntries() = %d, want %d", got, tt.dropped)
+			}
+			if reported != tt.dropped {
+				t.Errorf("reported %d dropped entries, want %d", reported, tt.dropped)
+			}
+		})
+	}
+}
+
+func TestAsyncWriterWriteAfterClose(t *testing.T) {
+	output := newBlockingWriter()
+	close(output.release)
+	w := newAsyncWriter(output, &AsyncConfig{}, nil)
+	w.Close()
+
+	w.WriteLevel(InfoLevel, []byte("a"))
+	if got := output.written(); got != "a" {
+		t.Errorf("written %q, want %q", got, "a")
+	}
+}
+
+func TestAsyncWriterFlush(t *testing.T) {
+	output := newBlockingWriter()
+	w := newAsyncWriter(output, &AsyncConfig{}, nil)
+	defer w.Close()
+
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() of empty buffer = %v", err)
+	}
+
+	w.WriteLevel(InfoLevel, []byte("a"))
+	<-output.started
+	w.WriteLevel(InfoLevel, []byte("b"))
+
+	goroutines := runtime.NumGoroutine()
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		if err := w.Flush(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Flush() = %v, want %v", err, context.DeadlineExceeded)
+		}
+		cancel()
+	}
+	if got := runtime.NumGoroutine(); got > goroutines {
+		t.Errorf("goroutines increase from %d to %d after Flush is timed out", goroutines, got)
+	}
+
+	close(output.release)
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if got := output.written(); got != "ab" {
+		t.Errorf("written %q, want %q", got, "ab")
+	}
+}
@@ -0,0 +1,321 @@
+package logadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// config keys of file, environment variable name is prefix + upper case key with "." replaced by "_", e.g. LOG_FILE_MAX_SIZE
+const (
+	configKeyLevel           = "level"
+	configKeyFormat          = "format"
+	configKeyTimestampFormat = "timestamp_format"
+	configKeyLevelSpec       = "level_spec"
+	configKeyUseLogFile      = "use_log_file"
+	configKeyDefaultFields   = "default_fields"
+	configKeyIgnoredPaths    = "ignored_paths"
+	configKeyFile            = "file"
+	configKeyFilename        = "file.filename"
+	configKeyMaxSize         = "file.max_size"
+	configKeyMaxBackups      = "file.max_backups"
+	configKeyMaxAge          = "file.max_age"
+	configKeyCompress        = "file.compress"
+	configKeyUseLocalTime    = "file.use_local_time"
+	configKeyRotation        = "file.rotation_interval"
+	configKeyFilenamePattern = "file.filename_pattern"
+)
+
+var configKeys = []string{
+	configKeyLevel,
+	configKeyFormat,
+	configKeyTimestampFormat,
+	configKeyLevelSpec,
+	configKeyUseLogFile,
+	configKeyDefaultFields,
+	configKeyIgnoredPaths,
+	configKeyFilename,
+	configKeyMaxSize,
+	configKeyMaxBackups,
+	configKeyMaxAge,
+	configKeyCompress,
+	configKeyUseLocalTime,
+	configKeyRotation,
+	configKeyFilenamePattern,
+}
+
+var rotationIntervalNames = map[RotationInterval]string{
+	RotateNone:   "none",
+	RotateHourly: "hourly",
+	RotateDaily:  "daily",
+}
+
+// Errors of invalid config, wrapped by ConfigError
+var (
+	ErrUnknownConfigKey        = errors.New("unknown config key")
+	ErrNegativeConfigValue     = errors.New("must not be negative")
+	ErrInvalidLevel            = errors.New("invalid log level")
+	ErrInvalidLogFormat        = errors.New("invalid log format")
+	ErrInvalidLevelSpec        = errors.New("invalid level spec")
+	ErrInvalidRotationInterval = errors.New("invalid rotation interval")
+	ErrInvalidOverflowPolicy   = errors.New("invalid overflow policy")
+	ErrInvalidRedactStrategy   = errors.New("invalid redact strategy")
+	ErrLogFileNotWritable      = errors.New("log file is not writable")
+)
+
+// ConfigError error of invalid config value, Key is file key, environment variable name or Config field, e.g. FileConfig.MaxSize
+type ConfigError struct {
+	Key string
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid config %s: %v", e.Key, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigFromEnv load config from environment variables with prefix, e.g. prefix LOG_ reads
+// LOG_LEVEL, LOG_FORMAT, LOG_TIMESTAMP_FORMAT, LOG_LEVEL_SPEC, LOG_USE_LOG_FILE, LOG_DEFAULT_FIELDS (key=value,key=value),
+// LOG_IGNORED_PATHS (comma separated), LOG_FILE_FILENAME, LOG_FILE_MAX_SIZE, LOG_FILE_MAX_BACKUPS, LOG_FILE_MAX_AGE,
+// LOG_FILE_COMPRESS, LOG_FILE_USE_LOCAL_TIME, LOG_FILE_ROTATION_INTERVAL and LOG_FILE_FILENAME_PATTERN.
+// Unset variables keep default config
+func ConfigFromEnv(prefix string) (*Config, error) {
+	values := make(map[string]interface{})
+	keyNames := make(map[string]string)
+	for _, key := range configKeys {
+		name := prefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		keyNames[key] = name
+		if value, ok := os.LookupEnv(name); ok {
+			values[key] = value
+		}
+	}
+
+	return newConfigFromValues(values, func(key string) string { return keyNames[key] })
+}
+
+// ConfigFromFile load config from YAML (.yaml, .yml) or JSON (.json) file, missing keys keep default config
+func ConfigFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &fields)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&fields)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension: %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	values, err := flattenConfigFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConfigFromValues(values, func(key string) string { return key })
+}
+
+// flattenConfigFields flatten nested file config fields to config keys, e.g. file.max_size
+func flattenConfigFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	for key, value := range fields {
+		if key != configKeyFile {
+			values[key] = value
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		fileFields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, &ConfigError{Key: key, Err: errors.New("must be an object")}
+		}
+		for fileKey, fileValue := range fileFields {
+			values[configKeyFile+"."+fileKey] = fileValue
+		}
+	}
+
+	return values, nil
+}
+
+func isConfigKey(key string) bool {
+	for _, configKey := range configKeys {
+		if key == configKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func configValueString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// newConfigFromValues returns default config overridden by values, keyName returns key name in error
+func newConfigFromValues(values map[string]interface{}, keyName func(key string) string) (*Config, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	config := getDefaultConfig()
+	var fileConfig *FileConfig
+	for _, key := range keys {
+		// unknown key is rejected even if its value is empty, e.g. typo levle: ""
+		if !isConfigKey(key) {
+			return nil, &ConfigError{Key: keyName(key), Err: ErrUnknownConfigKey}
+		}
+		value := strings.TrimSpace(configValueString(values[key]))
+		if len(value) == 0 {
+			continue
+		}
+		if strings.HasPrefix(key, configKeyFile+".") && fileConfig == nil {
+			// folder is created when log file is opened, loader does not touch file system
+			fileConfig = newDefaultFileConfig(defaultLogDir)
+			if _, ok := values[configKeyFilename]; ok {
+				// file name is fixed, not evaluated from default pattern
+				fileConfig.FilenamePattern = ""
+			}
+		}
+
+		var err error
+		switch key {
+		case configKeyLevel:
+			config.LogLevel, err = ParseLevel(value)
+		case configKeyFormat:
+			config.LogFormat, err = ParseLogFormat(value)
+		case configKeyTimestampFormat:
+			config.TimestampFormat = value
+		case configKeyLevelSpec:
+			_, err = parseLevelSpec(value)
+			config.LevelSpec = value
+		case configKeyUseLogFile:
+			config.IsUseLogFile, err = strconv.ParseBool(value)
+		case configKeyDefaultFields:
+			config.DefaultFields, err = parseConfigFields(values[key])
+		case configKeyIgnoredPaths:
+			config.IgnoredPaths, err = parseConfigList(values[key])
+		case configKeyFilename:
+			fileConfig.Filename = value
+		case configKeyMaxSize:
+			fileConfig.MaxSize, err = parseConfigInt(value)
+		case configKeyMaxBackups:
+			fileConfig.MaxBackups, err = parseConfigInt(value)
+		case configKeyMaxAge:
+			fileConfig.MaxAge, err = parseConfigInt(value)
+		case configKeyCompress:
+			fileConfig.IsCompress, err = strconv.ParseBool(value)
+		case configKeyUseLocalTime:
+			fileConfig.IsUseLocalTime, err = strconv.ParseBool(value)
+		case configKeyRotation:
+			fileConfig.RotationInterval, err = ParseRotationInterval(value)
+		case configKeyFilenamePattern:
+			fileConfig.FilenamePattern = value
+		}
+		if err != nil {
+			return nil, &ConfigError{Key: keyName(key), Err: err}
+		}
+	}
+	config.FileConfig = fileConfig
+
+	return config, nil
+}
+
+// parseConfigFields parses object of file or key=value,key=value string of environment variable
+func parseConfigFields(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case string:
+		fields := make(map[string]interface{})
+		for _, item := range strings.Split(v, ",") {
+			key, fieldValue, ok := strings.Cut(item, "=")
+			key = strings.TrimSpace(key)
+			if !ok || len(key) == 0 {
+				return nil, fmt.Errorf("invalid field %q, must be key=value", item)
+			}
+			fields[key] = strings.TrimSpace(fieldValue)
+		}
+		return fields, nil
+	}
+
+	return nil, errors.New("must be an object")
+}
+
+// parseConfigList parses list of file or comma separated string of environment variable
+func parseConfigList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, configValueString(item))
+		}
+		return list, nil
+	case string:
+		list := strings.Split(v, ",")
+		for i := range list {
+			list[i] = strings.TrimSpace(list[i])
+		}
+		return list, nil
+	}
+
+	return nil, errors.New("must be a list")
+}
+
+func parseConfigInt(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, ErrNegativeConfigValue
+	}
+
+	return n, nil
+}
+
+// ParseRotationInterval takes a string rotation interval and returns the rotation interval, e.g. none, hourly, daily
+func ParseRotationInterval(interval string) (RotationInterval, error) {
+	name := strings.ToLower(strings.TrimSpace(interval))
+	for rotation, rotationName := range rotationIntervalNames {
+		if name == rotationName {
+			return rotation, nil
+		}
+	}
+
+	return RotateNone, fmt.Errorf("%w: %q", ErrInvalidRotationInterval, interval)
+}
+
+// String convert rotation interval to string, e.g. none, hourly, daily
+func (interval RotationInterval) String() string {
+	if name, ok := rotationIntervalNames[interval]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("RotationInterval(%d)", uint32(interval))
+}
@@ -0,0 +1,265 @@
+package logadapter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFromEnvDoesNotCreateLogDir(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	filename := filepath.Join(dir, "app", "app.log")
+	t.Setenv("LOG_FILE_FILENAME", filename)
+	t.Setenv("LOG_FILE_MAX_SIZE", "5")
+
+	config, err := ConfigFromEnv("LOG_")
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if config.FileConfig == nil || config.FileConfig.Filename != filename || config.FileConfig.MaxSize != 5 {
+		t.Fatalf("ConfigFromEnv() file config = %+v", config.FileConfig)
+	}
+	for _, path := range []string{defaultLogDir, filepath.Dir(filename)} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s is created by loader", path)
+		}
+	}
+}
+
+func writeConfigFile(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestConfigFromFile(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "config.yaml",
+			data: `
+level: warn
+format: text
+timestamp_format: "2006-01-02"
+level_spec: payment=debug
+use_log_file: true
+default_fields:
+  service: order
+ignored_paths: [runtime/, vendor/]
+file:
+  filename: logs/app.log
+  max_size: 5
+  max_backups: 2
+  max_age: 7
+  compress: true
+  use_local_time: false
+  rotation_interval: hourly
+  filename_pattern: logs/app_2006-01-02-15.log
+`,
+		},
+		{
+			name: "config.json",
+			data: `{
+  "level": "warn",
+  "format": "text",
+  "timestamp_format": "2006-01-02",
+  "level_spec": "payment=debug",
+  "use_log_file": true,
+  "default_fields": {"service": "order"},
+  "ignored_paths": ["runtime/", "vendor/"],
+  "file": {
+    "filename": "logs/app.log",
+    "max_size": 5,
+    "max_backups": 2,
+    "max_age": 7,
+    "compress": true,
+    "use_local_time": false,
+    "rotation_interval": "hourly",
+    "filename_pattern": "logs/app_2006-01-02-15.log"
+  }
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ConfigFromFile(writeConfigFile(t, tt.name, tt.data))
+			if err != nil {
+				t.Fatalf("ConfigFromFile() error = %v", err)
+			}
+
+			if config.LogLevel != WarnLevel || config.LogFormat != TextFormat || config.TimestampFormat != "2006-01-02" ||
+				config.LevelSpec != "payment=debug" || !config.IsUseLogFile {
+				t.Errorf("config = %+v", config)
+			}
+			if config.DefaultFields["service"] != "order" {
+				t.Errorf("default fields = %v", config.DefaultFields)
+			}
+			if len(config.IgnoredPaths) != 2 || config.IgnoredPaths[0] != "runtime/" || config.IgnoredPaths[1] != "vendor/" {
+				t.Errorf("ignored paths = %v", config.IgnoredPaths)
+			}
+			want := FileConfig{
+				Filename:         "logs/app.log",
+				MaxSize:          5,
+				MaxBackups:       2,
+				MaxAge:           7,
+				IsCompress:       true,
+				RotationInterval: RotateHourly,
+				FilenamePattern:  "logs/app_2006-01-02-15.log",
+			}
+			if config.FileConfig == nil || *config.FileConfig != want {
+				t.Errorf("file config = %+v, want %+v", config.FileConfig, want)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"APP_LOG_LEVEL":                    "error",
+		"APP_LOG_FORMAT":                   "pretty_json",
+		"APP_LOG_TIMESTAMP_FORMAT":         "15:04",
+		"APP_LOG_LEVEL_SPEC":               "gorm=warn,*=info",
+		"APP_LOG_USE_LOG_FILE":             "true",
+		"APP_LOG_DEFAULT_FIELDS":           "service=order, env=dev",
+		"APP_LOG_IGNORED_PATHS":            "runtime/, vendor/",
+		"APP_LOG_FILE_FILENAME":            "app.log",
+		"APP_LOG_FILE_MAX_SIZE":            "5",
+		"APP_LOG_FILE_MAX_BACKUPS":         "2",
+		"APP_LOG_FILE_MAX_AGE":             "7",
+		"APP_LOG_FILE_COMPRESS":            "true",
+		"APP_LOG_FILE_USE_LOCAL_TIME":      "false",
+		"APP_LOG_FILE_ROTATION_INTERVAL":   "none",
+		"APP_LOG_FILE_FILENAME_PATTERN":    "",
+		"APP_LOG_NOT_A_CONFIG_KEY_IGNORED": "x",
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+
+	config, err := ConfigFromEnv("APP_LOG_")
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if config.LogLevel != ErrorLevel || config.LogFormat != PrettyJSONFormat || config.TimestampFormat != "15:04" ||
+		config.LevelSpec != "gorm=warn,*=info" || !config.IsUseLogFile {
+		t.Errorf("config = %+v", config)
+	}
+	if len(config.DefaultFields) != 2 || config.DefaultFields["env"] != "dev" {
+		t.Errorf("default fields = %v", config.DefaultFields)
+	}
+	if len(config.IgnoredPaths) != 2 || config.IgnoredPaths[1] != "vendor/" {
+		t.Errorf("ignored paths = %v", config.IgnoredPaths)
+	}
+	want := FileConfig{Filename: "app.log", MaxSize: 5, MaxBackups: 2, MaxAge: 7, IsCompress: true}
+	if config.FileConfig == nil || *config.FileConfig != want {
+		t.Errorf("file config = %+v, want %+v", config.FileConfig, want)
+	}
+}
+
+func TestConfigLoaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		load    func(t *testing.T) (*Config, error)
+		key     string
+		wantErr error
+	}{
+		{
+			name:    "env invalid level",
+			load:    envLoader(map[string]string{"LOG_LEVEL": "verbose"}),
+			key:     "LOG_LEVEL",
+			wantErr: ErrInvalidLevel,
+		},
+		{
+			name:    "env negative max size",
+			load:    envLoader(map[string]string{"LOG_FILE_MAX_SIZE": "-1"}),
+			key:     "LOG_FILE_MAX_SIZE",
+			wantErr: ErrNegativeConfigValue,
+		},
+		{
+			name:    "env invalid rotation interval",
+			load:    envLoader(map[string]string{"LOG_FILE_ROTATION_INTERVAL": "weekly"}),
+			key:     "LOG_FILE_ROTATION_INTERVAL",
+			wantErr: ErrInvalidRotationInterval,
+		},
+		{
+			name:    "file invalid format",
+			load:    fileLoader("config.yaml", "format: xml"),
+			key:     "format",
+			wantErr: ErrInvalidLogFormat,
+		},
+		{
+			name:    "file invalid level spec",
+			load:    fileLoader("config.json", `{"level_spec": "payment=loud"}`),
+			key:     "level_spec",
+			wantErr: ErrInvalidLevelSpec,
+		},
+		{
+			name:    "file unknown key",
+			load:    fileLoader("config.yaml", "levle: debug"),
+			key:     "levle",
+			wantErr: ErrUnknownConfigKey,
+		},
+		{
+			name:    "file unknown key with empty value",
+			load:    fileLoader("config.yaml", `levle: ""`),
+			key:     "levle",
+			wantErr: ErrUnknownConfigKey,
+		},
+		{
+			name:    "file unknown file key",
+			load:    fileLoader("config.json", `{"file": {"max_sise": null}}`),
+			key:     "file.max_sise",
+			wantErr: ErrUnknownConfigKey,
+		},
+		{
+			name:    "file negative max age",
+			load:    fileLoader("config.yaml", "file:\n  max_age: -3"),
+			key:     "file.max_age",
+			wantErr: ErrNegativeConfigValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := tt.load(t)
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("error = %v, config %+v, want ConfigError", err, config)
+			}
+			if configErr.Key != tt.key || !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v with key %q, want %v with key %q", err, configErr.Key, tt.wantErr, tt.key)
+			}
+		})
+	}
+}
+
+func envLoader(env map[string]string) func(t *testing.T) (*Config, error) {
+	return func(t *testing.T) (*Config, error) {
+		for name, value := range env {
+			t.Setenv(name, value)
+		}
+		return ConfigFromEnv("LOG_")
+	}
+}
+
+func fileLoader(name, data string) func(t *testing.T) (*Config, error) {
+	return func(t *testing.T) (*Config, error) {
+		return ConfigFromFile(writeConfigFile(t, name, data))
+	}
+}
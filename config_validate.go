@@ -0,0 +1,171 @@
+package logadapter
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// failedWriter writer returns error of creating log file on every write
+type failedWriter struct {
+	err error
+}
+
+func (w failedWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func (w failedWriter) Close() error { return nil }
+
+// NewWithConfigE returns a logger instance with custom configuration, like NewWithConfig but config is validated first.
+// Log file and its folder are created and checked writable. Returns *ConfigError with Key of invalid Config field,
+// e.g. FileConfig.MaxSize, wrapping ErrNegativeConfigValue, ErrInvalidLevel, ErrInvalidLogFormat, etc.
+func NewWithConfigE(config *Config) (*Logger, error) {
+	if config == nil {
+		config = getDefaultConfig()
+	}
+	if err := validateConfig(config); err != nil {
+		return nil, err
+	}
+
+	if config.IsUseLogFile && len(config.Sinks) == 0 {
+		fileConfig := config.FileConfig
+		if fileConfig == nil {
+			var err error
+			if fileConfig, err = getDefaultFileConfig(); err != nil {
+				return nil, &ConfigError{Key: "FileConfig", Err: err}
+			}
+		}
+		if err := checkLogFile(logFilename(fileConfig, time.Now())); err != nil {
+			return nil, &ConfigError{Key: "FileConfig.Filename", Err: fmt.Errorf("%w: %w", ErrLogFileNotWritable, err)}
+		}
+
+		c := *config
+		c.FileConfig = fileConfig
+		config = &c
+	}
+
+	return NewWithConfig(config), nil
+}
+
+// validateConfig returns *ConfigError of first invalid field of config
+func validateConfig(config *Config) error {
+	if err := validateLevel(config.LogLevel); err != nil {
+		return &ConfigError{Key: "LogLevel", Err: err}
+	}
+	if err := validateLogFormat(config.LogFormat); err != nil {
+		return &ConfigError{Key: "LogFormat", Err: err}
+	}
+	if len(config.LevelSpec) > 0 {
+		if _, err := parseLevelSpec(config.LevelSpec); err != nil {
+			return &ConfigError{Key: "LevelSpec", Err: err}
+		}
+	}
+	if config.IsUseLogFile && len(config.Sinks) == 0 && config.FileConfig != nil {
+		if err := validateFileConfig(config.FileConfig); err != nil {
+			return err
+		}
+	}
+	if async := config.Async; async != nil {
+		switch {
+		case async.BufferSize < 0:
+			return &ConfigError{Key: "Async.BufferSize", Err: ErrNegativeConfigValue}
+		case async.OverflowPolicy > OverflowDropBelowLevel:
+			return &ConfigError{Key: "Async.OverflowPolicy", Err: fmt.Errorf("%w: %d", ErrInvalidOverflowPolicy, async.OverflowPolicy)}
+		case async.ReportInterval < 0:
+			return &ConfigError{Key: "Async.ReportInterval", Err: ErrNegativeConfigValue}
+		}
+		if err := validateLevel(async.DropLevel); err != nil {
+			return &ConfigError{Key: "Async.DropLevel", Err: err}
+		}
+	}
+	for i, sink := range config.Sinks {
+		if err := validateLevel(sink.LogLevel); err != nil {
+			return &ConfigError{Key: fmt.Sprintf("Sinks[%d].LogLevel", i), Err: err}
+		}
+		if err := validateLogFormat(sink.LogFormat); err != nil {
+			return &ConfigError{Key: fmt.Sprintf("Sinks[%d].LogFormat", i), Err: err}
+		}
+	}
+	if redaction := config.Redaction; redaction != nil {
+		for i, rule := range redaction.FieldRules {
+			key := fmt.Sprintf("Redaction.FieldRules[%d]", i)
+			if len(rule.Field) == 0 {
+				return &ConfigError{Key: key + ".Field", Err: errors.New("must not be empty")}
+			}
+			if err := validateRedactRule(key, rule.Strategy, rule.TruncateLength); err != nil {
+				return err
+			}
+		}
+		for i, rule := range redaction.ValueRules {
+			key := fmt.Sprintf("Redaction.ValueRules[%d]", i)
+			if rule.Pattern == nil {
+				return &ConfigError{Key: key + ".Pattern", Err: errors.New("must not be null")}
+			}
+			if err := validateRedactRule(key, rule.Strategy, rule.TruncateLength); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateFileConfig(fileConfig *FileConfig) error {
+	switch {
+	case fileConfig.MaxSize < 0:
+		return &ConfigError{Key: "FileConfig.MaxSize", Err: ErrNegativeConfigValue}
+	case fileConfig.MaxBackups < 0:
+		return &ConfigError{Key: "FileConfig.MaxBackups", Err: ErrNegativeConfigValue}
+	case fileConfig.MaxAge < 0:
+		return &ConfigError{Key: "FileConfig.MaxAge", Err: ErrNegativeConfigValue}
+	case fileConfig.RotationInterval > RotateDaily:
+		return &ConfigError{
+			Key: "FileConfig.RotationInterval",
+			Err: fmt.Errorf("%w: %d", ErrInvalidRotationInterval, fileConfig.RotationInterval),
+		}
+	case len(logFilename(fileConfig, time.Now())) == 0:
+		return &ConfigError{Key: "FileConfig.Filename", Err: errors.New("must not be empty")}
+	}
+
+	return nil
+}
+
+func validateLevel(level Level) error {
+	if level > TraceLevel {
+		return fmt.Errorf("%w: %d", ErrInvalidLevel, level)
+	}
+
+	return nil
+}
+
+func validateLogFormat(logFormat LogFormat) error {
+	if _, ok := logFormatNames[logFormat]; !ok {
+		return fmt.Errorf("%w: %d", ErrInvalidLogFormat, logFormat)
+	}
+
+	return nil
+}
+
+func validateRedactRule(key string, strategy RedactStrategy, truncateLength int) error {
+	if strategy > RedactTruncate {
+		return &ConfigError{Key: key + ".Strategy", Err: fmt.Errorf("%w: %d", ErrInvalidRedactStrategy, strategy)}
+	}
+	if truncateLength < 0 {
+		return &ConfigError{Key: key + ".TruncateLength", Err: ErrNegativeConfigValue}
+	}
+
+	return nil
+}
+
+// logFilename returns name of current log file, evaluated from FilenamePattern if file is rotated at wall-clock interval
+func logFilename(fileConfig *FileConfig, now time.Time) string {
+	if fileConfig.RotationInterval == RotateNone || len(fileConfig.FilenamePattern) == 0 {
+		return fileConfig.Filename
+	}
+	if !fileConfig.IsUseLocalTime {
+		now = now.UTC()
+	}
+	dir, layout := filepath.Split(fileConfig.FilenamePattern)
+
+	return filepath.Join(dir, now.Format(layout))
+}
@@ -0,0 +1,103 @@
+package logadapter
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestNewWithConfigEErrors(t *testing.T) {
+	// regular file can not be a folder of log file
+	notDir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  Config
+		key     string
+		wantErr error
+	}{
+		{name: "invalid level", config: Config{LogLevel: TraceLevel + 1}, key: "LogLevel", wantErr: ErrInvalidLevel},
+		{name: "invalid format", config: Config{LogFormat: LogFormat(100)}, key: "LogFormat", wantErr: ErrInvalidLogFormat},
+		{name: "invalid level spec", config: Config{LevelSpec: "payment=loud"}, key: "LevelSpec", wantErr: ErrInvalidLevelSpec},
+		{
+			name:    "negative max size",
+			config:  Config{IsUseLogFile: true, FileConfig: &FileConfig{Filename: "app.log", MaxSize: -1}},
+			key:     "FileConfig.MaxSize",
+			wantErr: ErrNegativeConfigValue,
+		},
+		{
+			name:    "invalid rotation interval",
+			config:  Config{IsUseLogFile: true, FileConfig: &FileConfig{Filename: "app.log", RotationInterval: RotateDaily + 1}},
+			key:     "FileConfig.RotationInterval",
+			wantErr: ErrInvalidRotationInterval,
+		},
+		{
+			name:    "unwritable log folder",
+			config:  Config{IsUseLogFile: true, FileConfig: &FileConfig{Filename: filepath.Join(notDir, "app.log")}},
+			key:     "FileConfig.Filename",
+			wantErr: ErrLogFileNotWritable,
+		},
+		{
+			name:    "invalid sink level",
+			config:  Config{Sinks: []Sink{{Writer: io.Discard}, {Writer: io.Discard, LogLevel: TraceLevel + 1}}},
+			key:     "Sinks[1].LogLevel",
+			wantErr: ErrInvalidLevel,
+		},
+		{
+			name:    "negative async buffer size",
+			config:  Config{Async: &AsyncConfig{BufferSize: -1}},
+			key:     "Async.BufferSize",
+			wantErr: ErrNegativeConfigValue,
+		},
+		{
+			name:    "invalid redact strategy",
+			config:  Config{Redaction: &RedactionConfig{ValueRules: []ValueRule{{Pattern: regexp.MustCompile(`x`), Strategy: RedactTruncate + 1}}}},
+			key:     "Redaction.ValueRules[0].Strategy",
+			wantErr: ErrInvalidRedactStrategy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewWithConfigE(&tt.config)
+			if logger != nil {
+				t.Errorf("NewWithConfigE() returns logger with invalid config")
+			}
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("error = %v, want ConfigError", err)
+			}
+			if configErr.Key != tt.key || !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v with key %q, want %v with key %q", err, configErr.Key, tt.wantErr, tt.key)
+			}
+		})
+	}
+}
+
+func TestNewWithConfigELogFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app", "app.log")
+	logger, err := NewWithConfigE(&Config{
+		LogLevel:      InfoLevel,
+		IsUseLogFile:  true,
+		FileConfig:    &FileConfig{Filename: filename},
+		ConsoleWriter: io.Discard,
+	})
+	if err != nil {
+		t.Fatalf("NewWithConfigE() error = %v", err)
+	}
+	defer logger.SetLogConsole()
+
+	logger.Info("file message")
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("log file is not created, %v", err)
+	}
+	if !regexp.MustCompile(`file message`).Match(data) {
+		t.Errorf("log file = %q", data)
+	}
+}
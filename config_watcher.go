@@ -0,0 +1,239 @@
+package logadapter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultConfigWatchInterval = 5 * time.Second
+
+// configWatcher poll config file and apply changed config to logger
+type configWatcher struct {
+	logger   *Logger
+	path     string
+	interval time.Duration
+	config   *Config
+	modTime  time.Time
+	size     int64
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// WatchConfigFile poll config file every interval, default 5 seconds, and apply changed config.
+// See Logger.WatchConfigFile
+func WatchConfigFile(path string, interval time.Duration) (stop func(), err error) {
+	return GetLogger().WatchConfigFile(path, interval)
+}
+
+// WatchConfigFile poll config file every interval, default 5 seconds, and apply changed level, format, timestamp format,
+// level spec, default fields, ignored paths and file settings. Current config file is expected to be applied already,
+// e.g. by NewWithConfig(ConfigFromFile(path)). A config_reloaded entry is logged with diff of changed keys,
+// invalid config file is rejected and previous config is kept. Returns function to stop watching
+func (l *Logger) WatchConfigFile(path string, interval time.Duration) (stop func(), err error) {
+	l = l.root()
+	if interval <= 0 {
+		interval = defaultConfigWatchInterval
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := ConfigFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &configWatcher{
+		logger:   l,
+		path:     path,
+		interval: interval,
+		config:   config,
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		stop:     make(chan struct{}),
+	}
+	go watcher.run()
+
+	return watcher.close, nil
+}
+
+func (w *configWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+func (w *configWatcher) close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// poll reload config file if its modification time or size is changed
+func (w *configWatcher) poll() {
+	info, err := os.Stat(w.path)
+	if err != nil || (info.ModTime().Equal(w.modTime) && info.Size() == w.size) {
+		return
+	}
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+
+	config, err := ConfigFromFile(w.path)
+	if err != nil {
+		w.logger.WithFields(log.Fields{
+			"type":       LogTypeError,
+			"path":       w.path,
+			log.ErrorKey: err.Error(),
+		}).Error("config file is rejected, previous config is kept")
+		return
+	}
+
+	diff := configDiff(w.config, config)
+	if len(diff) == 0 {
+		return
+	}
+	w.logger.applyConfig(config, diff)
+	w.config = config
+
+	// entry is always logged, even new level is higher than info
+	level := InfoLevel
+	if currentLevel := w.logger.GetLogLevel(); currentLevel < level {
+		level = currentLevel
+		if level < ErrorLevel {
+			level = ErrorLevel
+		}
+	}
+	w.logger.WithFields(log.Fields{
+		"type": LogTypeConfigReloaded,
+		"path": w.path,
+		"diff": diff,
+	}).Log(log.Level(level), "config reloaded")
+}
+
+// applyConfig apply changed keys of config at once. New formatter and output are built first,
+// then they are swapped with level, ignored paths and level spec in one critical section
+func (l *Logger) applyConfig(config *Config, diff map[string]interface{}) {
+	changed := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := diff[key]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	isFormatChanged := changed(configKeyFormat, configKeyTimestampFormat, configKeyDefaultFields)
+	isLevelSpecChanged := changed(configKeyLevelSpec)
+	isOutputChanged := changed(configKeyUseLogFile, configKeyFilename, configKeyMaxSize, configKeyMaxBackups, configKeyMaxAge,
+		configKeyCompress, configKeyUseLocalTime, configKeyRotation, configKeyFilenamePattern)
+
+	timestampFormat := config.TimestampFormat
+	if len(timestampFormat) == 0 {
+		timestampFormat = DefaultTimestampFormat
+	}
+	formatter := newFormatter(config.LogFormat, timestampFormat)
+	defaultFields := cloneLogFields(config.DefaultFields)
+	ignoredPaths := append([]string(nil), config.IgnoredPaths...)
+	// level spec is validated by config loader
+	rules, err := parseLevelSpec(config.LevelSpec)
+	if err != nil {
+		isLevelSpecChanged = false
+	}
+	var output io.Writer = l.console()
+	var file io.WriteCloser
+	if isOutputChanged && config.IsUseLogFile {
+		fileConfig := config.FileConfig
+		if fileConfig == nil {
+			fileConfig, err = getDefaultFileConfig()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set log file, %v\n", err)
+		} else {
+			file = NewFileWriter(fileConfig)
+			output = io.MultiWriter(l.console(), file)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if changed(configKeyLevel) {
+		l.isLevelSet.Store(true)
+		l.Logger.SetLevel(log.Level(config.LogLevel))
+	}
+	if isFormatChanged {
+		l.logFormat = config.LogFormat
+		l.timestampFormat = config.TimestampFormat
+		l.defaultFields = defaultFields
+		l.formatter = formatter
+		l.setSinkFormat(config.LogFormat, timestampFormat)
+	}
+	if changed(configKeyIgnoredPaths) {
+		l.ignoredPaths.Store(&ignoredPaths)
+	}
+	if isLevelSpecChanged {
+		l.setLevelRules(rules)
+	}
+	// output or formatter chain is rebuilt once, named loggers are updated after that
+	if isOutputChanged {
+		l.setOutput(output)
+		l.fileOutput = file
+	} else {
+		l.setFormatter(l.formatter)
+	}
+}
+
+// configDiff returns changed keys of config with old and new values
+func configDiff(previous, current *Config) map[string]interface{} {
+	previousValues := configValues(previous)
+	currentValues := configValues(current)
+
+	diff := make(map[string]interface{})
+	for _, key := range configKeys {
+		if !reflect.DeepEqual(previousValues[key], currentValues[key]) {
+			diff[key] = map[string]interface{}{"old": previousValues[key], "new": currentValues[key]}
+		}
+	}
+
+	return diff
+}
+
+// configValues returns values of config keys
+func configValues(config *Config) map[string]interface{} {
+	values := map[string]interface{}{
+		configKeyLevel:           config.LogLevel.String(),
+		configKeyFormat:          config.LogFormat.String(),
+		configKeyTimestampFormat: config.TimestampFormat,
+		configKeyLevelSpec:       config.LevelSpec,
+		configKeyUseLogFile:      config.IsUseLogFile,
+	}
+	if len(config.DefaultFields) > 0 {
+		values[configKeyDefaultFields] = config.DefaultFields
+	}
+	if len(config.IgnoredPaths) > 0 {
+		values[configKeyIgnoredPaths] = config.IgnoredPaths
+	}
+	if fileConfig := config.FileConfig; fileConfig != nil {
+		values[configKeyFilename] = fileConfig.Filename
+		values[configKeyMaxSize] = fileConfig.MaxSize
+		values[configKeyMaxBackups] = fileConfig.MaxBackups
+		values[configKeyMaxAge] = fileConfig.MaxAge
+		values[configKeyCompress] = fileConfig.IsCompress
+		values[configKeyUseLocalTime] = fileConfig.IsUseLocalTime
+		values[configKeyRotation] = fileConfig.RotationInterval.String()
+		values[configKeyFilenamePattern] = fileConfig.FilenamePattern
+	}
+
+	return values
+}
@@ -0,0 +1,126 @@
+package logadapter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// readLogFile returns decoded JSON entries of log file
+func readLogFile(t *testing.T, filename string) []map[string]interface{} {
+	t.Helper()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("decode entry %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.yaml")
+	writeConfig := func(level, filename, fields string) {
+		t.Helper()
+		data := fmt.Sprintf("level: %s\nformat: json\nuse_log_file: true\ndefault_fields: %s\nfile:\n  filename: %s\n",
+			level, fields, filepath.Join(dir, filename))
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig("info", "before.log", "{}")
+
+	config, err := ConfigFromFile(path)
+	if err != nil {
+		t.Fatalf("ConfigFromFile() error = %v", err)
+	}
+	// console output is not needed in test
+	config.ConsoleWriter = io.Discard
+	logger, err := NewWithConfigE(config)
+	if err != nil {
+		t.Fatalf("NewWithConfigE() error = %v", err)
+	}
+
+	stop, err := logger.WatchConfigFile(path, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchConfigFile() error = %v", err)
+	}
+	defer stop()
+
+	// entries are logged while config is reloaded
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, named := range []*Logger{logger, logger.Named("payment")} {
+		wg.Add(1)
+		go func(named *Logger) {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					named.Warn("concurrent message")
+					time.Sleep(time.Millisecond)
+				}
+			}
+		}(named)
+	}
+	writeConfig("warn", "after.log", "{app: payment}")
+
+	var reloaded map[string]interface{}
+	deadline := time.Now().Add(2 * time.Second)
+	for reloaded == nil && time.Now().Before(deadline) {
+		for _, entry := range readLogFile(t, filepath.Join(dir, "after.log")) {
+			if entry["type"] == LogTypeConfigReloaded {
+				reloaded = entry
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(done)
+	wg.Wait()
+	if reloaded == nil {
+		t.Fatal("config_reloaded entry is not logged")
+	}
+	diff, _ := reloaded["diff"].(map[string]interface{})
+	for _, key := range []string{configKeyLevel, configKeyDefaultFields, configKeyFilename} {
+		if _, ok := diff[key]; !ok {
+			t.Errorf("diff has no %s: %v", key, diff)
+		}
+	}
+
+	logger.Info("dropped message")
+	logger.Warn("after message")
+	for _, entry := range readLogFile(t, filepath.Join(dir, "after.log")) {
+		if entry["app"] != "payment" {
+			t.Errorf("entry has no default field app: %v", entry)
+		}
+		if entry["level"] == "info" {
+			t.Errorf("info entry is logged after level is warn: %v", entry)
+		}
+	}
+	for _, entry := range readLogFile(t, filepath.Join(dir, "before.log")) {
+		if msg, _ := entry["msg"].(string); strings.Contains(msg, "after") || entry["app"] != nil {
+			t.Errorf("entry of new config is written to previous file: %v", entry)
+		}
+	}
+}
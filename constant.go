@@ -2,15 +2,20 @@ package logadapter
 
 // custom logtype
 const (
-	LogTypeAPI      = "api"
-	LogTypeRequest  = "request"
-	LogTypeResponse = "response"
-	LogTypeError    = "error"
-	LogTypeDebug    = "debug"
-	LogTypeInfo     = "info"
-	LogTypeWarn     = "warn"
-	LogTypeSQL      = "sql"
-	LogTypeTrace    = "trace"
+	LogTypeAPI            = "api"
+	LogTypeRequest        = "request"
+	LogTypeResponse       = "response"
+	LogTypeError          = "error"
+	LogTypeDebug          = "debug"
+	LogTypeInfo           = "info"
+	LogTypeWarn           = "warn"
+	LogTypeSQL            = "sql"
+	LogTypeTrace          = "trace"
+	LogTypeHTTPClient     = "http_client"
+	LogTypeGRPC           = "grpc"
+	LogTypeGRPCClient     = "grpc_client"
+	LogTypeStdLog         = "std_log"
+	LogTypeConfigReloaded = "config_reloaded"
 )
 
 // custom constants
@@ -18,6 +23,8 @@ const (
 	DefaultTimestampFormat = "2006-01-02 15:04:05.00000"
 	DefaultPrefix          = "LogAdapter_"
 	DefaultSourceField     = "stack_trace"
+	DefaultModuleField     = "module"
+	DefaultLevelSpecEnv    = "LOG_LEVEL_SPEC"
 )
 
 // Export HeaderKey constanst
@@ -25,6 +32,13 @@ const (
 	CorrelationIDHeaderKey HeaderKey = "X-User-Correlation-Id"
 	RequestIDHeaderKey     HeaderKey = "X-Request-ID"
 	UserInfoHeaderKey      HeaderKey = "X-Userinfo"
+	TraceParentHeaderKey   HeaderKey = "traceparent"
+	TraceStateHeaderKey    HeaderKey = "tracestate"
+	B3HeaderKey            HeaderKey = "b3"
+	B3TraceIDHeaderKey     HeaderKey = "X-B3-TraceId"
+	B3SpanIDHeaderKey      HeaderKey = "X-B3-SpanId"
+	B3SampledHeaderKey     HeaderKey = "X-B3-Sampled"
+	B3FlagsHeaderKey       HeaderKey = "X-B3-Flags"
 )
 
 // Export LogKey constanst
@@ -32,11 +46,14 @@ const (
 	CorrelationIDLogKey LogKey = "correlation_id"
 	RequestIDLogKey     LogKey = "request_id"
 	UserInfoLogKey      LogKey = "user_info"
+	TraceIDLogKey       LogKey = "trace_id"
+	SpanIDLogKey        LogKey = "span_id"
+	TraceFlagsLogKey    LogKey = "trace_flags"
 )
 
 // Export default LogKeyMap
 var (
-	DefaultLogKeys []LogKey = []LogKey{CorrelationIDLogKey, RequestIDLogKey, UserInfoLogKey}
+	DefaultLogKeys []LogKey = []LogKey{CorrelationIDLogKey, RequestIDLogKey, UserInfoLogKey, TraceIDLogKey, SpanIDLogKey, TraceFlagsLogKey}
 	baseSourceDir  string
 )
 
@@ -47,6 +64,37 @@ const (
 	TextFormat
 )
 
+// log file rotation interval
+const (
+	RotateNone RotationInterval = iota
+	RotateHourly
+	RotateDaily
+)
+
+// redaction strategy
+const (
+	// RedactMask replace value by RedactedMask
+	RedactMask RedactStrategy = iota
+	// RedactHash replace value by its sha256 hash
+	RedactHash
+	// RedactDrop remove field, or remove matched value
+	RedactDrop
+	// RedactTruncate keep first characters of value
+	RedactTruncate
+)
+
+// async overflow policy
+const (
+	// OverflowBlock block until buffer has free space
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drop new log entry
+	OverflowDropNewest
+	// OverflowDropOldest drop oldest log entry in buffer
+	OverflowDropOldest
+	// OverflowDropBelowLevel drop new log entry if its level is below AsyncConfig.DropLevel, block others
+	OverflowDropBelowLevel
+)
+
 const (
 	// PanicLevel level, highest level of severity. Logs and then calls panic with the
 	// message passed to Debug, Info, ...
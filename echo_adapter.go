@@ -2,12 +2,11 @@ package logadapter
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
-	"strconv"
-	"strings"
+	"net/http"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/log"
 	"github.com/sirupsen/logrus"
@@ -18,9 +17,9 @@ type EchoLogger struct {
 	*Logger
 }
 
-// NewEchoLogger return singleton logger
+// NewEchoLogger return named logger "echo" of singleton logger
 func NewEchoLogger() *EchoLogger {
-	return &EchoLogger{Logger: l}
+	return &EchoLogger{Logger: GetLogger().Named("echo")}
 }
 
 // To logrus.Level
@@ -57,22 +56,27 @@ func toEchoLevel(level logrus.Level) log.Lvl {
 
 // Output return logger io.Writer
 func (l *EchoLogger) Output() io.Writer {
-	return l.Out
+	root := l.Logger.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	return root.Out
 }
 
 // SetOutput logger io.Writer
+// Output is owned by root logger, it's changed for root logger and all its named loggers
 func (l *EchoLogger) SetOutput(w io.Writer) {
-	l.Out = w
+	l.Logger.root().SetOutput(w)
 }
 
 // Level return logger level
 func (l *EchoLogger) Level() log.Lvl {
-	return toEchoLevel(l.Logger.Level)
+	return toEchoLevel(l.Logger.GetLevel())
 }
 
 // SetLevel logger level
 func (l *EchoLogger) SetLevel(v log.Lvl) {
-	l.Logger.Level = toLogrusLevel(v)
+	l.Logger.SetLevel(Level(toLogrusLevel(v)))
 }
 
 // SetHeader logger header
@@ -84,13 +88,22 @@ func (l *EchoLogger) SetHeader(h string) {
 
 // Formatter return logger formatter
 func (l *EchoLogger) Formatter() logrus.Formatter {
-	return l.Logger.Formatter
+	root := l.Logger.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	return root.formatter
 }
 
 // SetFormatter logger formatter
-// Only support logrus formatter
+// Only support logrus formatter. Formatter is owned by root logger, it's changed for root logger and all its named loggers,
+// sinks, redaction, default fields and module field are kept
 func (l *EchoLogger) SetFormatter(formatter logrus.Formatter) {
-	l.Logger.Formatter = formatter
+	root := l.Logger.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	root.setFormatter(formatter)
 }
 
 // Prefix return logger prefix
@@ -238,96 +251,200 @@ func (l *EchoLogger) Panicj(j log.JSON) {
 	l.Logger.Panicln(string(b))
 }
 
-func setEchoContext(c echo.Context) {
-	var correlationID, requestID, userInfor string
-	ctx := c.Request().Context()
-	correlationID = c.Request().Header.Get(string(CorrelationIDHeaderKey))
-	if correlationID == "" {
-		correlationID = generateCorrelationID()
-	}
-	ctx = setContextKeyValue(ctx, string(CorrelationIDLogKey), correlationID)
+// EchoLoggerConfig config for echo logger middleware
+type EchoLoggerConfig struct {
+	Headers     []HeaderMapping    // request headers are logged with log keys, if empty use DefaultHeaderMappings
+	IsEnableB3  bool               // parse and emit B3 headers beside W3C traceparent and tracestate headers
+	BodyCapture *BodyCaptureConfig // log request and response body at level of access entry, set null if not log body
 
-	requestID = c.Request().Header.Get(string(RequestIDHeaderKey))
-	if requestID == "" {
-		requestID = uuid.NewString()
-	}
-	ctx = setContextKeyValue(ctx, string(RequestIDLogKey), requestID)
+	Skipper         func(c echo.Context) bool // skip access log if returns true, context fields are still set
+	ExcludedPaths   []string                  // route path patterns of c.Path() are not logged, e.g. /healthz, /internal/*, /static/**
+	ExcludedMethods []string                  // HTTP methods are not logged, e.g. OPTIONS
+	RouteLevels     []RouteLevel              // log level of routes, first matched is used, default InfoLevel
 
-	userInfor = c.Request().Header.Get(string(UserInfoHeaderKey))
-	if userInfor != "" {
-		ctx = setContextKeyValue(ctx, string(UserInfoLogKey), userInfor)
-	}
+	StatusLevels  []StatusLevel // log level of response status ranges, override route level, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration // request slower than threshold is logged at Warn with slow=true, 0 to disable
+}
 
-	c.SetRequest(c.Request().WithContext(ctx))
-	c.Response().Header().Set(string(CorrelationIDHeaderKey), correlationID)
-	c.Response().Header().Set(string(RequestIDHeaderKey), requestID)
+// DefaultEchoLoggerConfig default config for echo logger middleware
+var DefaultEchoLoggerConfig = EchoLoggerConfig{
+	Headers: DefaultHeaderMappings,
+}
+
+func setEchoContext(c echo.Context, config EchoLoggerConfig) {
+	req := c.Request()
+	ctx := setRequestContext(req.Context(), req.Header, c.Response().Header(), config.Headers, config.IsEnableB3)
+	c.SetRequest(req.WithContext(ctx))
 }
 
 // NewEchoLoggerMiddleware returns a middleware that logs HTTP requests.
 func NewEchoLoggerMiddleware() echo.MiddlewareFunc {
+	return NewEchoLoggerMiddlewareWithConfig(DefaultEchoLoggerConfig)
+}
+
+// NewEchoLoggerMiddlewareWithConfig returns a middleware that logs HTTP requests with config.
+func NewEchoLoggerMiddlewareWithConfig(config EchoLoggerConfig) echo.MiddlewareFunc {
+	if len(config.Headers) == 0 {
+		config.Headers = DefaultHeaderMappings
+	}
+	if len(config.StatusLevels) == 0 {
+		config.StatusLevels = DefaultStatusLevels
+	}
+	capture := newBodyCapture(config.BodyCapture)
+
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			req := c.Request()
 			res := c.Response()
 
-			setEchoContext(c)
+			setEchoContext(c, config)
+			if config.isSkipped(c) {
+				return next(c)
+			}
+
+			var requestBody map[string]interface{}
+			var captureWriter *bodyCaptureWriter
+			if capture.isEnabled(echoRoute(c)) {
+				if contentType := req.Header.Get(echo.HeaderContentType); capture.isAllowedContentType(contentType) {
+					body, truncated, _ := capture.captureRequest(c.Request())
+					requestBody = capture.logFields(LogTypeRequest, c, contentType, body, truncated)
+				}
+				captureWriter = &bodyCaptureWriter{ResponseWriter: res.Writer, maxBytes: capture.maxBytes}
+				res.Writer = captureWriter
+			}
 
 			start := time.Now()
-			var err error
-			var errStr string
-			if err = next(c); err != nil {
+			err := next(c)
+			if err != nil {
 				c.Error(err)
-				b, _ := json.Marshal(err.Error())
-				b = b[1 : len(b)-1]
-				errStr = string(b)
 			}
 			stop := time.Now()
-			reqSizeStr := req.Header.Get(echo.HeaderContentLength)
-			if reqSizeStr == "" {
-				reqSizeStr = "0"
-			}
-			reqSize, _ := strconv.ParseInt(reqSizeStr, 10, 64)
 
 			// * log json format
 			latency := stop.Sub(start)
-			trace := map[string]interface{}{
-				"ip":         c.RealIP(),
-				"user_agent": req.UserAgent(),
-				"host":       req.Host,
-				"method":     req.Method,
-				"url":        req.URL.Path,
-				"uri":        req.RequestURI,
-				"status":     res.Status,
-				"byte_in":    reqSize,
-				"byte_out":   res.Size,
-				"latency":    latency.String(),
-				"latency_ms": latency.Milliseconds(),
-				"referer":    req.Referer(),
-				"type":       LogTypeAPI,
+			trace := accessLogFields(req, c.RealIP(), res.Status, res.Size, latency, err)
+			level, isSlow := accessLogLevel(res.Status, err != nil, config.StatusLevels, config.routeLevel(c), latency, config.SlowThreshold)
+			if isSlow {
+				trace["slow"] = true
 			}
 
-			if !strings.EqualFold(errStr, "") {
-				trace["error"] = errStr
+			// body entries are logged at level of access entry, after level is known
+			if requestBody != nil {
+				logEchoFields(c, level, requestBody)
+			}
+			if captureWriter != nil {
+				res.Writer = captureWriter.ResponseWriter
+				if contentType := res.Header().Get(echo.HeaderContentType); capture.isAllowedContentType(contentType) {
+					body := captureWriter.body.Bytes()
+					logEchoFields(c, level, capture.logFields(LogTypeResponse, c, contentType, body, captureWriter.truncated))
+				}
 			}
 
 			fields := mergeLogFields(trace, GetLogFieldFromContext(c.Request().Context()))
-			if logger, ok := c.Logger().(*EchoLogger); ok {
-				if !strings.EqualFold(errStr, "") {
-					logger.WithFields(fields).Error()
-				} else {
-					logger.WithFields(fields).Info()
+			logEchoFields(c, level, fields)
+
+			return err
+		}
+	}
+}
+
+// isSkipped check access log is skipped by skipper, excluded paths or excluded methods
+func (config EchoLoggerConfig) isSkipped(c echo.Context) bool {
+	if config.Skipper != nil && config.Skipper(c) {
+		return true
+	}
+
+	return isExcludedRequest(c.Request().Method, echoRoute(c), config.ExcludedMethods, config.ExcludedPaths)
+}
+
+// routeLevel get log level of route, default InfoLevel
+func (config EchoLoggerConfig) routeLevel(c echo.Context) Level {
+	return routeLevel(c.Request().Method, echoRoute(c), config.RouteLevels)
+}
+
+// echoRoute returns route path of c.Path(), or URL path if route is not found
+func echoRoute(c echo.Context) string {
+	if route := c.Path(); route != "" {
+		return route
+	}
+
+	return c.Request().URL.Path
+}
+
+// EchoRecoverConfig config for echo recover middleware
+type EchoRecoverConfig struct {
+	ErrorHandler func(c echo.Context, err error) error // returns error response of recovered panic, default echo.ErrInternalServerError
+	IsRePanic    bool                                  // panic again after logging, ErrorHandler is not called
+}
+
+// DefaultEchoRecoverConfig default config for echo recover middleware
+var DefaultEchoRecoverConfig = EchoRecoverConfig{
+	ErrorHandler: func(c echo.Context, err error) error {
+		return echo.ErrInternalServerError
+	},
+}
+
+// NewEchoRecoverMiddleware returns a middleware that recovers from panics and logs them with stack trace.
+// Use it after NewEchoLoggerMiddleware to log context fields
+func NewEchoRecoverMiddleware() echo.MiddlewareFunc {
+	return NewEchoRecoverMiddlewareWithConfig(DefaultEchoRecoverConfig)
+}
+
+// NewEchoRecoverMiddlewareWithConfig returns a middleware that recovers from panics and logs them with stack trace.
+// Use it after NewEchoLoggerMiddleware to log context fields
+func NewEchoRecoverMiddlewareWithConfig(config EchoRecoverConfig) echo.MiddlewareFunc {
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = DefaultEchoRecoverConfig.ErrorHandler
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (returnErr error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
 				}
-			} else {
-				if !strings.EqualFold(errStr, "") {
-					c.Logger().Errorj(fields)
-				} else {
-					c.Logger().Infoj(fields)
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				err, ok := r.(error)
+				if !ok {
+					err = fmt.Errorf("%v", r)
 				}
-			}
 
-			return err
+				fields := mergeLogFields(map[string]interface{}{
+					"type":             LogTypeError,
+					"method":           c.Request().Method,
+					"url":              c.Request().URL.Path,
+					"route":            c.Path(),
+					logrus.ErrorKey:    err.Error(),
+					DefaultSourceField: GetLogger().getCaller(),
+				}, GetLogFieldFromContext(c.Request().Context()))
+				logEchoFields(c, ErrorLevel, fields, "panic recovered")
+
+				if config.IsRePanic {
+					panic(r)
+				}
+				returnErr = config.ErrorHandler(c, err)
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// logEchoFields log fields with level by root logger of echo logger if it's EchoLogger, otherwise by singleton logger.
+// Level of echo logger is kept, entries have no module field, same as entries of gin, net/http and gRPC middlewares.
+// Echo logger of other types is not used, entries must pass redaction of logger
+func logEchoFields(c echo.Context, level Level, fields map[string]interface{}, args ...interface{}) {
+	logger := GetLogger()
+	if echoLogger, ok := c.Logger().(*EchoLogger); ok {
+		if !echoLogger.IsLevelEnabled(logrus.Level(level)) {
+			return
 		}
+		logger = echoLogger.Logger.root()
 	}
+	logger.WithFields(fields).Log(logrus.Level(level), args...)
 }
 
 // LogWithEchoContext log content with echo context
@@ -335,6 +452,7 @@ func NewEchoLoggerMiddleware() echo.MiddlewareFunc {
 // content[1] : log type -> string,
 // content[2] : log field -> map[string]interface{}
 func LogWithEchoContext(c echo.Context, content ...interface{}) {
+	l := GetLogger()
 	var logType string
 	if len(content) > 1 {
 		if value, ok := content[1].(string); ok && value != "" {
@@ -0,0 +1,153 @@
+package logadapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/sirupsen/logrus"
+)
+
+func TestEchoLoggerSetFormatter(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := NewWithConfig(&Config{
+		LogLevel:  DebugLevel,
+		Sinks:     []Sink{{Writer: buf, LogLevel: DebugLevel, LogFormat: JSONFormat}},
+		Redaction: &RedactionConfig{ValueRules: []ValueRule{{Pattern: regexp.MustCompile(`secret`)}}},
+	})
+	echoLogger := &EchoLogger{Logger: logger.Named("echo")}
+
+	echoLogger.SetFormatter(&logrus.JSONFormatter{})
+	echoLogger.Info("secret message")
+	// parent change is inherited, formatter of echo logger is kept
+	logger.SetLevel(InfoLevel)
+	echoLogger.Info("after parent change")
+
+	entries := buf.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("%d entries are written, want 2", len(entries))
+	}
+	for i, msg := range []string{RedactedMask + " message", "after parent change"} {
+		if entries[i]["msg"] != msg || entries[i][DefaultModuleField] != "echo" {
+			t.Errorf("entry %d = %v, want msg %q and module echo", i, entries[i], msg)
+		}
+	}
+}
+
+func TestEchoLoggerSetOutput(t *testing.T) {
+	logger := NewWithConfig(&Config{LogLevel: DebugLevel})
+	echoLogger := &EchoLogger{Logger: logger.Named("echo")}
+
+	buf := &syncBuffer{}
+	echoLogger.SetOutput(buf)
+	echoLogger.SetFormatter(&logrus.JSONFormatter{})
+	echoLogger.Info("echo message")
+	logger.SetFormatter(JSONFormat)
+	logger.Info("root message")
+
+	entries := buf.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("%d entries are written, want 2", len(entries))
+	}
+	if entries[0]["msg"] != "echo message" || entries[0][DefaultModuleField] != "echo" {
+		t.Errorf("echo entry = %v", entries[0])
+	}
+	if entries[1]["msg"] != "root message" {
+		t.Errorf("root entry = %v", entries[1])
+	}
+}
+
+func TestEchoBodyCaptureLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		path   string
+		status int
+		level  string
+		config BodyCaptureConfig
+	}{
+		{name: "route level", route: "/debug/*", path: "/debug/vars", status: http.StatusOK, level: "debug"},
+		{name: "status level", route: "/orders", path: "/orders", status: http.StatusInternalServerError, level: "error"},
+		{
+			name:   "included route",
+			route:  "/users/:id",
+			path:   "/users/1",
+			status: http.StatusOK,
+			level:  "info",
+			config: BodyCaptureConfig{IncludedRoutes: []string{"/users/*"}},
+		},
+		{
+			name:   "URL path of unknown route",
+			path:   "/static/app.js",
+			status: http.StatusNotFound,
+			level:  "warning",
+			config: BodyCaptureConfig{IncludedRoutes: []string{"/static/**"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := setTestLogger(t)
+			e := echo.New()
+			e.Use(NewEchoLoggerMiddlewareWithConfig(EchoLoggerConfig{
+				BodyCapture: &tt.config,
+				RouteLevels: []RouteLevel{{Path: "/debug/**", Level: DebugLevel}},
+			}))
+			if tt.route != "" {
+				e.POST(tt.route, func(c echo.Context) error {
+					return c.String(tt.status, "response")
+				})
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader("request"))
+			req.Header.Set(echo.HeaderContentType, echo.MIMETextPlain)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			levels := make(map[interface{}]interface{})
+			for _, entry := range buf.entries(t) {
+				levels[entry["type"]] = entry["level"]
+			}
+			for _, logType := range []string{LogTypeRequest, LogTypeResponse, LogTypeAPI} {
+				if levels[logType] != tt.level {
+					t.Errorf("%s entry level = %v, want %s, entries %v", logType, levels[logType], tt.level, levels)
+				}
+			}
+		})
+	}
+}
+
+func TestEchoAccessEntryWithEchoLogger(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   log.Lvl
+		entries int
+	}{
+		{name: "info", level: log.INFO, entries: 1},
+		{name: "error", level: log.ERROR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := setTestLogger(t)
+			e := echo.New()
+			e.Logger = NewEchoLogger()
+			e.Logger.SetLevel(tt.level)
+			e.Use(NewEchoLoggerMiddleware())
+			e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			entries := buf.entries(t)
+			if len(entries) != tt.entries {
+				t.Fatalf("%d entries are written, want %d", len(entries), tt.entries)
+			}
+			for _, entry := range entries {
+				if module, ok := entry[DefaultModuleField]; ok {
+					t.Errorf("access entry has module %v", module)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package logadapter
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultBodyCaptureMaxBytes = 4096
+
+// BodyCaptureConfig config for log request and response body in echo logger middleware
+type BodyCaptureConfig struct {
+	MaxBytes       int                      // max logged bytes of each body, default 4096
+	ContentTypes   []string                 // allowed content types, type ends with "/" is prefix. If empty use DefaultBodyCaptureContentTypes
+	IncludedRoutes []string                 // route path patterns of c.Path(), or URL path if route is not found, e.g. /internal/*, /static/**. If not empty only capture these routes
+	ExcludedRoutes []string                 // route path patterns of c.Path(), or URL path if route is not found, never capture these routes
+	Redact         func(body []byte) []byte // redact body before logging, set null if not redact
+}
+
+// DefaultBodyCaptureContentTypes default allowed content types: JSON, form and text
+var DefaultBodyCaptureContentTypes = []string{
+	echo.MIMEApplicationJSON,
+	"application/problem+json",
+	echo.MIMEApplicationForm,
+	"text/",
+}
+
+// bodyCapture capture request and response body of allowed routes and content types
+type bodyCapture struct {
+	maxBytes       int
+	contentTypes   []string
+	includedRoutes []string
+	excludedRoutes []string
+	redact         func(body []byte) []byte
+}
+
+func newBodyCapture(config *BodyCaptureConfig) *bodyCapture {
+	if config == nil {
+		return nil
+	}
+
+	capture := &bodyCapture{
+		maxBytes:       config.MaxBytes,
+		contentTypes:   config.ContentTypes,
+		includedRoutes: config.IncludedRoutes,
+		excludedRoutes: config.ExcludedRoutes,
+		redact:         config.Redact,
+	}
+	if capture.maxBytes <= 0 {
+		capture.maxBytes = defaultBodyCaptureMaxBytes
+	}
+	if len(capture.contentTypes) == 0 {
+		capture.contentTypes = DefaultBodyCaptureContentTypes
+	}
+
+	return capture
+}
+
+// isEnabled check body capture is enabled for route
+func (bc *bodyCapture) isEnabled(route string) bool {
+	if bc == nil || matchAnyRoute(bc.excludedRoutes, route) {
+		return false
+	}
+
+	return len(bc.includedRoutes) == 0 || matchAnyRoute(bc.includedRoutes, route)
+}
+
+// isAllowedContentType check content type is in allowed list
+func (bc *bodyCapture) isAllowedContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range bc.contentTypes {
+		if strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed) {
+			return true
+		}
+		if strings.EqualFold(mediaType, allowed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// captureRequest read first max bytes of request body, request body is restored for handler
+func (bc *bodyCapture) captureRequest(req *http.Request) (body []byte, truncated bool, err error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, false, nil
+	}
+
+	body, err = io.ReadAll(io.LimitReader(req.Body, int64(bc.maxBytes)+1))
+	req.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(body), req.Body),
+		Closer: req.Body,
+	}
+	if len(body) > bc.maxBytes {
+		return body[:bc.maxBytes], true, err
+	}
+
+	return body, false, err
+}
+
+// logFields returns body log fields
+func (bc *bodyCapture) logFields(logType string, c echo.Context, contentType string, body []byte, truncated bool) map[string]interface{} {
+	if bc.redact != nil {
+		body = bc.redact(body)
+	}
+
+	return mergeLogFields(map[string]interface{}{
+		"type":           logType,
+		"method":         c.Request().Method,
+		"url":            c.Request().URL.Path,
+		"route":          c.Path(),
+		"content_type":   contentType,
+		"body":           string(body),
+		"body_truncated": truncated,
+	}, GetLogFieldFromContext(c.Request().Context()))
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyCaptureWriter write response and keep first max bytes of response body
+type bodyCaptureWriter struct {
+	http.ResponseWriter
+	body      bytes.Buffer
+	maxBytes  int
+	truncated bool
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	if remain := w.maxBytes - w.body.Len(); remain > 0 {
+		if len(b) > remain {
+			w.body.Write(b[:remain])
+			w.truncated = true
+		} else {
+			w.body.Write(b)
+		}
+	} else if len(b) > 0 {
+		w.truncated = true
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher
+func (w *bodyCaptureWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker
+func (w *bodyCaptureWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// Unwrap returns original response writer for http.ResponseController
+func (w *bodyCaptureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
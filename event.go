@@ -0,0 +1,180 @@
+package logadapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Event is a chainable log entry with typed fields, use Logger.With to create one
+//
+//	logadapter.With(ctx).Type(logadapter.LogTypeAPI).Str("key", "value").Int("count", 1).Err(err).Info("message")
+type Event struct {
+	logger  *Logger
+	ctx     context.Context
+	logType string
+	fields  map[string]interface{}
+}
+
+// With returns new log event with log fields from context
+func With(ctx context.Context) *Event { return GetLogger().With(ctx) }
+
+// With returns new log event with log fields from context
+func (l *Logger) With(ctx context.Context) *Event {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return &Event{
+		logger: l,
+		ctx:    ctx,
+		fields: make(map[string]interface{}),
+	}
+}
+
+// Type set log type, level of Msg is chosen by log type
+func (e *Event) Type(logType string) *Event {
+	e.logType = logType
+	return e
+}
+
+// Str add string field
+func (e *Event) Str(key, value string) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Int add int field
+func (e *Event) Int(key string, value int) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Int64 add int64 field
+func (e *Event) Int64(key string, value int64) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Uint64 add uint64 field
+func (e *Event) Uint64(key string, value uint64) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Float64 add float64 field
+func (e *Event) Float64(key string, value float64) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Bool add bool field
+func (e *Event) Bool(key string, value bool) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Dur add duration field as string, same as latency field
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	e.fields[key] = value.String()
+	return e
+}
+
+// Time add time field
+func (e *Event) Time(key string, value time.Time) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Stringer add field with value of String(), nil value is logged as null
+func (e *Event) Stringer(key string, value fmt.Stringer) *Event {
+	if value == nil {
+		e.fields[key] = nil
+		return e
+	}
+	e.fields[key] = value.String()
+	return e
+}
+
+// Any add field with any value
+func (e *Event) Any(key string, value interface{}) *Event {
+	e.fields[key] = value
+	return e
+}
+
+// Fields add many fields
+func (e *Event) Fields(fields map[string]interface{}) *Event {
+	for k, v := range fields {
+		e.fields[k] = v
+	}
+	return e
+}
+
+// Err add error field, nil error is ignored
+func (e *Event) Err(err error) *Event {
+	if err != nil {
+		e.fields[log.ErrorKey] = err
+	}
+	return e
+}
+
+// Trace log with trace level
+func (e *Event) Trace(msg string) { e.log(TraceLevel, msg) }
+
+// Debug log with debug level
+func (e *Event) Debug(msg string) { e.log(DebugLevel, msg) }
+
+// Info log with info level
+func (e *Event) Info(msg string) { e.log(InfoLevel, msg) }
+
+// Warn log with warn level
+func (e *Event) Warn(msg string) { e.log(WarnLevel, msg) }
+
+// Error log with error level
+func (e *Event) Error(msg string) { e.log(ErrorLevel, msg) }
+
+// Fatal log with fatal level
+func (e *Event) Fatal(msg string) { e.log(FatalLevel, msg) }
+
+// Panic log with panic level
+func (e *Event) Panic(msg string) { e.log(PanicLevel, msg) }
+
+// Msg log with level of log type, same as LogWithContext
+func (e *Event) Msg(msg string) { e.log(levelFromLogType(e.logType), msg) }
+
+func (e *Event) log(level Level, msg string) {
+	if level != FatalLevel && !e.logger.IsLevelEnabled(log.Level(level)) {
+		return
+	}
+
+	fields := mergeLogFields(e.logger.GetLogFieldFromContext(e.ctx), e.fields)
+	if e.logType != "" {
+		fields["type"] = e.logType
+	}
+	if level <= WarnLevel {
+		fields[DefaultSourceField] = e.logger.getCaller()
+	}
+
+	entry := e.logger.WithContext(e.ctx).WithFields(fields)
+	if level == FatalLevel {
+		entry.Fatal(msg)
+		return
+	}
+	entry.Log(log.Level(level), msg)
+}
+
+// levelFromLogType get log level of log type
+func levelFromLogType(logType string) Level {
+	switch logType {
+	case LogTypeAPI, LogTypeInfo, LogTypeRequest, LogTypeResponse:
+		return InfoLevel
+	case LogTypeError:
+		return ErrorLevel
+	case LogTypeWarn:
+		return WarnLevel
+	}
+
+	return DebugLevel
+}
@@ -0,0 +1,110 @@
+package logadapter
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// GinLoggerConfig config for gin logger middleware
+type GinLoggerConfig struct {
+	Headers    []HeaderMapping // request headers are logged with log keys, if empty use DefaultHeaderMappings
+	IsEnableB3 bool            // parse and emit B3 headers beside W3C traceparent and tracestate headers
+
+	Skipper         func(c *gin.Context) bool // skip access log if returns true, context fields are still set
+	ExcludedPaths   []string                  // route path patterns of c.FullPath() are not logged, e.g. /healthz, /internal/*, /static/**
+	ExcludedMethods []string                  // HTTP methods are not logged, e.g. OPTIONS
+	RouteLevels     []RouteLevel              // log level of routes, first matched is used, default InfoLevel
+
+	StatusLevels  []StatusLevel // log level of response status ranges, override route level, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration // request slower than threshold is logged at Warn with slow=true, 0 to disable
+}
+
+// DefaultGinLoggerConfig default config for gin logger middleware
+var DefaultGinLoggerConfig = GinLoggerConfig{
+	Headers: DefaultHeaderMappings,
+}
+
+func setGinContext(c *gin.Context, config GinLoggerConfig) {
+	ctx := setRequestContext(c.Request.Context(), c.Request.Header, c.Writer.Header(), config.Headers, config.IsEnableB3)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// NewGinLoggerMiddleware returns a gin middleware that logs HTTP requests, same as NewEchoLoggerMiddleware.
+func NewGinLoggerMiddleware() gin.HandlerFunc {
+	return NewGinLoggerMiddlewareWithConfig(DefaultGinLoggerConfig)
+}
+
+// NewGinLoggerMiddlewareWithConfig returns a gin middleware that logs HTTP requests with config.
+func NewGinLoggerMiddlewareWithConfig(config GinLoggerConfig) gin.HandlerFunc {
+	if len(config.Headers) == 0 {
+		config.Headers = DefaultHeaderMappings
+	}
+	if len(config.StatusLevels) == 0 {
+		config.StatusLevels = DefaultStatusLevels
+	}
+
+	return func(c *gin.Context) {
+		setGinContext(c, config)
+		if config.isSkipped(c) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		stop := time.Now()
+
+		var err error
+		if lastErr := c.Errors.Last(); lastErr != nil {
+			err = lastErr.Err
+		}
+		size := int64(c.Writer.Size())
+		if size < 0 {
+			size = 0
+		}
+
+		// * log json format
+		latency := stop.Sub(start)
+		trace := accessLogFields(c.Request, c.ClientIP(), c.Writer.Status(), size, latency, err)
+		level, isSlow := accessLogLevel(c.Writer.Status(), err != nil, config.StatusLevels, config.routeLevel(c), latency, config.SlowThreshold)
+		if isSlow {
+			trace["slow"] = true
+		}
+
+		fields := mergeLogFields(trace, GetLogFieldFromContext(c.Request.Context()))
+		GetLogger().WithFields(fields).Log(logrus.Level(level))
+	}
+}
+
+// isSkipped check access log is skipped by skipper, excluded paths or excluded methods
+func (config GinLoggerConfig) isSkipped(c *gin.Context) bool {
+	if config.Skipper != nil && config.Skipper(c) {
+		return true
+	}
+
+	return isExcludedRequest(c.Request.Method, ginRoute(c), config.ExcludedMethods, config.ExcludedPaths)
+}
+
+// routeLevel get log level of route, default InfoLevel
+func (config GinLoggerConfig) routeLevel(c *gin.Context) Level {
+	return routeLevel(c.Request.Method, ginRoute(c), config.RouteLevels)
+}
+
+// ginRoute returns route path of c.FullPath(), or URL path if route is not found
+func ginRoute(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+
+	return c.Request.URL.Path
+}
+
+// LogWithGinContext log content with gin context
+// content[0] : message -> interface{},
+// content[1] : log type -> string,
+// content[2] : log field -> map[string]interface{}
+func LogWithGinContext(c *gin.Context, content ...interface{}) {
+	LogWithContext(c.Request.Context(), content...)
+}
@@ -19,12 +19,12 @@ type GormLogger struct {
 	Debug                 bool
 }
 
-// NewGormLogger new gorm logger
+// NewGormLogger new gorm logger, named logger "gorm" of singleton logger
 func NewGormLogger() *GormLogger {
 	return &GormLogger{
 		SkipErrRecordNotFound: true,
 		Debug:                 true,
-		Logger:                l,
+		Logger:                GetLogger().Named("gorm"),
 		SlowThreshold:         time.Second,
 		SourceField:           DefaultSourceField,
 	}
@@ -0,0 +1,423 @@
+package logadapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCLoggerConfig config for gRPC server interceptors
+type GRPCLoggerConfig struct {
+	Headers    []HeaderMapping // request metadata are logged with log keys, if empty use DefaultHeaderMappings
+	IsEnableB3 bool            // parse and emit B3 metadata beside W3C traceparent and tracestate metadata
+
+	Skipper         func(ctx context.Context, fullMethod string) bool // skip access log if returns true, context fields are still set
+	ExcludedMethods []string                                          // full method patterns are not logged, e.g. /grpc.health.v1.Health/*
+
+	StatusLevels  []StatusLevel // log level of HTTP status mapped from gRPC code, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration // call slower than threshold is logged at Warn with slow=true, 0 to disable
+}
+
+// DefaultGRPCLoggerConfig default config for gRPC server interceptors
+var DefaultGRPCLoggerConfig = GRPCLoggerConfig{
+	Headers: DefaultHeaderMappings,
+}
+
+// GRPCClientConfig config for gRPC client interceptors
+type GRPCClientConfig struct {
+	Headers    []HeaderMapping // context fields are set to outgoing metadata, if empty use DefaultPropagatedHeaderMappings
+	IsEnableB3 bool            // emit B3 metadata beside W3C traceparent and tracestate metadata
+
+	Skipper         func(ctx context.Context, fullMethod string) bool // skip access log if returns true, metadata are still set
+	ExcludedMethods []string                                          // full method patterns are not logged, e.g. /grpc.health.v1.Health/*
+
+	StatusLevels  []StatusLevel // log level of HTTP status mapped from gRPC code, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration // call slower than threshold is logged at Warn with slow=true, 0 to disable
+}
+
+// DefaultGRPCClientConfig default config for gRPC client interceptors
+var DefaultGRPCClientConfig = GRPCClientConfig{
+	Headers: DefaultPropagatedHeaderMappings,
+}
+
+// NewGRPCUnaryServerInterceptor returns a unary server interceptor that logs gRPC calls.
+func NewGRPCUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return NewGRPCUnaryServerInterceptorWithConfig(DefaultGRPCLoggerConfig)
+}
+
+// NewGRPCUnaryServerInterceptorWithConfig returns a unary server interceptor that logs gRPC calls with config.
+func NewGRPCUnaryServerInterceptorWithConfig(config GRPCLoggerConfig) grpc.UnaryServerInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, responseMD := setGRPCContext(ctx, config)
+		if len(responseMD) > 0 {
+			_ = grpc.SetHeader(ctx, responseMD)
+		}
+		if config.isSkipped(ctx, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		call := newGRPCCall(LogTypeGRPC, info.FullMethod, "unary", grpcPeer(ctx))
+		call.received.Add(1)
+		res, err := handler(ctx, req)
+		if err == nil {
+			call.sent.Add(1)
+		}
+		call.log(ctx, err, config.StatusLevels, config.SlowThreshold)
+
+		return res, err
+	}
+}
+
+// NewGRPCStreamServerInterceptor returns a stream server interceptor that logs gRPC calls.
+func NewGRPCStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return NewGRPCStreamServerInterceptorWithConfig(DefaultGRPCLoggerConfig)
+}
+
+// NewGRPCStreamServerInterceptorWithConfig returns a stream server interceptor that logs gRPC calls with config.
+func NewGRPCStreamServerInterceptorWithConfig(config GRPCLoggerConfig) grpc.StreamServerInterceptor {
+	config = config.withDefaults()
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, responseMD := setGRPCContext(ss.Context(), config)
+		if len(responseMD) > 0 {
+			_ = ss.SetHeader(responseMD)
+		}
+		if config.isSkipped(ctx, info.FullMethod) {
+			return handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx})
+		}
+
+		call := newGRPCCall(LogTypeGRPC, info.FullMethod, grpcStreamType(info.IsClientStream, info.IsServerStream), grpcPeer(ctx))
+		err := handler(srv, &grpcServerStream{ServerStream: ss, ctx: ctx, call: call})
+		call.log(ctx, err, config.StatusLevels, config.SlowThreshold)
+
+		return err
+	}
+}
+
+// NewGRPCUnaryClientInterceptor returns a unary client interceptor propagates correlation id and request id of context
+func NewGRPCUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return NewGRPCUnaryClientInterceptorWithConfig(DefaultGRPCClientConfig)
+}
+
+// NewGRPCUnaryClientInterceptorWithConfig returns a unary client interceptor propagates context fields with config
+func NewGRPCUnaryClientInterceptorWithConfig(config GRPCClientConfig) grpc.UnaryClientInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		outgoingCtx := setGRPCOutgoingContext(ctx, config)
+		if config.isSkipped(ctx, method) {
+			return invoker(outgoingCtx, method, req, reply, cc, opts...)
+		}
+
+		call := newGRPCCall(LogTypeGRPCClient, method, "unary", cc.Target())
+		err := invoker(outgoingCtx, method, req, reply, cc, opts...)
+		call.sent.Add(1)
+		if err == nil {
+			call.received.Add(1)
+		}
+		call.log(ctx, err, config.StatusLevels, config.SlowThreshold)
+
+		return err
+	}
+}
+
+// NewGRPCStreamClientInterceptor returns a stream client interceptor propagates correlation id and request id of context
+func NewGRPCStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return NewGRPCStreamClientInterceptorWithConfig(DefaultGRPCClientConfig)
+}
+
+// NewGRPCStreamClientInterceptorWithConfig returns a stream client interceptor propagates context fields with config
+func NewGRPCStreamClientInterceptorWithConfig(config GRPCClientConfig) grpc.StreamClientInterceptor {
+	config = config.withDefaults()
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		outgoingCtx := setGRPCOutgoingContext(ctx, config)
+		if config.isSkipped(ctx, method) {
+			return streamer(outgoingCtx, desc, cc, method, opts...)
+		}
+
+		call := newGRPCCall(LogTypeGRPCClient, method, grpcStreamType(desc.ClientStreams, desc.ServerStreams), cc.Target())
+		cs, err := streamer(outgoingCtx, desc, cc, method, opts...)
+		if err != nil {
+			call.log(ctx, err, config.StatusLevels, config.SlowThreshold)
+			return nil, err
+		}
+
+		return &grpcClientStream{
+			ClientStream:   cs,
+			call:           call,
+			isServerStream: desc.ServerStreams,
+			finish: func(err error) {
+				call.log(ctx, err, config.StatusLevels, config.SlowThreshold)
+			},
+		}, nil
+	}
+}
+
+func (config GRPCLoggerConfig) withDefaults() GRPCLoggerConfig {
+	if len(config.Headers) == 0 {
+		config.Headers = DefaultHeaderMappings
+	}
+	if len(config.StatusLevels) == 0 {
+		config.StatusLevels = DefaultStatusLevels
+	}
+
+	return config
+}
+
+// isSkipped check access log is skipped by skipper or excluded methods
+func (config GRPCLoggerConfig) isSkipped(ctx context.Context, fullMethod string) bool {
+	if config.Skipper != nil && config.Skipper(ctx, fullMethod) {
+		return true
+	}
+
+	return isExcludedRequest("", fullMethod, nil, config.ExcludedMethods)
+}
+
+func (config GRPCClientConfig) withDefaults() GRPCClientConfig {
+	if len(config.Headers) == 0 {
+		config.Headers = DefaultPropagatedHeaderMappings
+	}
+	if len(config.StatusLevels) == 0 {
+		config.StatusLevels = DefaultStatusLevels
+	}
+
+	return config
+}
+
+// isSkipped check access log is skipped by skipper or excluded methods
+func (config GRPCClientConfig) isSkipped(ctx context.Context, fullMethod string) bool {
+	if config.Skipper != nil && config.Skipper(ctx, fullMethod) {
+		return true
+	}
+
+	return isExcludedRequest("", fullMethod, nil, config.ExcludedMethods)
+}
+
+// setGRPCContext sets log fields of incoming metadata to context, returns new context and response metadata
+func setGRPCContext(ctx context.Context, config GRPCLoggerConfig) (context.Context, metadata.MD) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := make(http.Header, len(md))
+	for key, values := range md {
+		header[http.CanonicalHeaderKey(key)] = values
+	}
+
+	responseHeader := http.Header{}
+	ctx = setRequestContext(ctx, header, responseHeader, config.Headers, config.IsEnableB3)
+
+	responseMD := make(metadata.MD, len(responseHeader))
+	for key, values := range responseHeader {
+		responseMD.Set(key, values...)
+	}
+
+	return ctx, responseMD
+}
+
+// setGRPCOutgoingContext sets context fields and trace context to outgoing metadata, existing metadata are kept
+func setGRPCOutgoingContext(ctx context.Context, config GRPCClientConfig) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	for _, mapping := range config.Headers {
+		if len(md.Get(string(mapping.Header))) > 0 {
+			continue
+		}
+		if value, ok := getContextKeyValue(ctx, string(mapping.LogKey)).(string); ok && value != "" {
+			md.Set(string(mapping.Header), value)
+		}
+	}
+	if len(md.Get(string(TraceParentHeaderKey))) == 0 {
+		header := http.Header{}
+		injectTraceContext(header, trace.SpanContextFromContext(ctx), config.IsEnableB3)
+		for key, values := range header {
+			md.Set(key, values...)
+		}
+	}
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// grpcCall access log of gRPC call
+type grpcCall struct {
+	logType    string
+	method     string
+	streamType string
+	peer       string
+	start      time.Time
+	received   atomic.Int64
+	sent       atomic.Int64
+}
+
+func newGRPCCall(logType, method, streamType, peer string) *grpcCall {
+	return &grpcCall{
+		logType:    logType,
+		method:     method,
+		streamType: streamType,
+		peer:       peer,
+		start:      time.Now(),
+	}
+}
+
+// log emits access log, level is get from HTTP status mapped from gRPC code
+func (call *grpcCall) log(ctx context.Context, err error, statusLevels []StatusLevel, slowThreshold time.Duration) {
+	latency := time.Since(call.start)
+	code := status.Code(err)
+	httpStatus := grpcHTTPStatus(code)
+
+	fields := map[string]interface{}{
+		"type":         call.logType,
+		"method":       call.method,
+		"grpc_type":    call.streamType,
+		"peer":         call.peer,
+		"code":         code.String(),
+		"status":       httpStatus,
+		"latency":      latency.String(),
+		"latency_ms":   latency.Milliseconds(),
+		"msg_received": call.received.Load(),
+		"msg_sent":     call.sent.Load(),
+	}
+	if err != nil {
+		fields[logrus.ErrorKey] = status.Convert(err).Message()
+	}
+	level, isSlow := accessLogLevel(httpStatus, err != nil, statusLevels, InfoLevel, latency, slowThreshold)
+	if isSlow {
+		fields["slow"] = true
+	}
+
+	GetLogger().WithFields(mergeLogFields(fields, GetLogFieldFromContext(ctx))).Log(logrus.Level(level))
+}
+
+// grpcServerStream wraps grpc.ServerStream with log context and message counts
+type grpcServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	call *grpcCall
+}
+
+func (s *grpcServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *grpcServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil && s.call != nil {
+		s.call.sent.Add(1)
+	}
+
+	return err
+}
+
+func (s *grpcServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.call != nil {
+		s.call.received.Add(1)
+	}
+
+	return err
+}
+
+// grpcClientStream wraps grpc.ClientStream with message counts, logs call once when stream is finished
+type grpcClientStream struct {
+	grpc.ClientStream
+	call           *grpcCall
+	isServerStream bool
+	finish         func(err error)
+	once           sync.Once
+}
+
+func (s *grpcClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.call.sent.Add(1)
+	} else if err != io.EOF {
+		s.done(err)
+	}
+
+	return err
+}
+
+func (s *grpcClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		s.call.received.Add(1)
+		// client streaming and unary response is finished after first message
+		if !s.isServerStream {
+			s.done(nil)
+		}
+	case errors.Is(err, io.EOF):
+		s.done(nil)
+	default:
+		s.done(err)
+	}
+
+	return err
+}
+
+func (s *grpcClientStream) done(err error) {
+	s.once.Do(func() { s.finish(err) })
+}
+
+func grpcPeer(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return ""
+}
+
+func grpcStreamType(isClientStream, isServerStream bool) string {
+	switch {
+	case isClientStream && isServerStream:
+		return "bidi_stream"
+	case isClientStream:
+		return "client_stream"
+	case isServerStream:
+		return "server_stream"
+	}
+
+	return "unary"
+}
+
+// grpcHTTPStatus map gRPC code to HTTP status, same as grpc-gateway
+func grpcHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
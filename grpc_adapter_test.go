@@ -0,0 +1,192 @@
+package logadapter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGRPCTestClient starts in-process health server with logger interceptors and returns connected client
+func newGRPCTestClient(t *testing.T) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(NewGRPCUnaryServerInterceptor()),
+		grpc.StreamInterceptor(NewGRPCStreamServerInterceptor()),
+	)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("payment", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(NewGRPCUnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(NewGRPCStreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// setTestLogger replaces singleton logger until test is finished
+func setTestLogger(t *testing.T) *syncBuffer {
+	t.Helper()
+
+	buf := &syncBuffer{}
+	previous := GetLogger()
+	SetLogger(newTestLogger(buf))
+	t.Cleanup(func() { SetLogger(previous) })
+
+	return buf
+}
+
+// waitEntry waits until entry of log type is logged, server entry is logged after client receives response
+func waitEntry(t *testing.T, buf *syncBuffer, logType string) map[string]interface{} {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, entry := range buf.entries(t) {
+			if entry["type"] == logType {
+				return entry
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s entry is not logged", logType)
+
+	return nil
+}
+
+func assertEntry(t *testing.T, entry map[string]interface{}, want map[string]interface{}) {
+	t.Helper()
+
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("entry[%s] = %v, want %v, entry %v", key, entry[key], value, entry)
+		}
+	}
+}
+
+func TestGRPCUnaryInterceptors(t *testing.T) {
+	tests := []struct {
+		name         string
+		service      string
+		code         codes.Code
+		serverFields map[string]interface{}
+		clientFields map[string]interface{}
+	}{
+		{
+			name:    "ok",
+			service: "payment",
+			code:    codes.OK,
+			serverFields: map[string]interface{}{
+				"level": "info", "code": "OK", "status": float64(200), "msg_received": float64(1), "msg_sent": float64(1),
+			},
+			clientFields: map[string]interface{}{
+				"level": "info", "code": "OK", "status": float64(200), "msg_received": float64(1), "msg_sent": float64(1),
+			},
+		},
+		{
+			name:    "not found",
+			service: "unknown",
+			code:    codes.NotFound,
+			serverFields: map[string]interface{}{
+				"level": "warning", "code": "NotFound", "status": float64(404), "msg_received": float64(1), "msg_sent": float64(0),
+			},
+			clientFields: map[string]interface{}{
+				"level": "warning", "code": "NotFound", "status": float64(404), "msg_received": float64(0), "msg_sent": float64(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := setTestLogger(t)
+			client := newGRPCTestClient(t)
+
+			ctx := setContextKeyValue(context.Background(), string(CorrelationIDLogKey), "correlation-1")
+			var header metadata.MD
+			_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: tt.service}, grpc.Header(&header))
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("Check() code = %v, want %v", code, tt.code)
+			}
+			if values := header.Get(string(CorrelationIDHeaderKey)); len(values) != 1 || values[0] != "correlation-1" {
+				t.Errorf("response metadata %s = %v, want correlation-1", CorrelationIDHeaderKey, values)
+			}
+
+			method := "/grpc.health.v1.Health/Check"
+			serverEntry := waitEntry(t, buf, LogTypeGRPC)
+			assertEntry(t, serverEntry, tt.serverFields)
+			assertEntry(t, serverEntry, map[string]interface{}{
+				"method": method, "grpc_type": "unary", string(CorrelationIDLogKey): "correlation-1",
+			})
+			if _, ok := serverEntry[string(RequestIDLogKey)]; !ok {
+				t.Errorf("server entry has no generated %s: %v", RequestIDLogKey, serverEntry)
+			}
+			clientEntry := waitEntry(t, buf, LogTypeGRPCClient)
+			assertEntry(t, clientEntry, tt.clientFields)
+			assertEntry(t, clientEntry, map[string]interface{}{
+				"method": method, "grpc_type": "unary", string(CorrelationIDLogKey): "correlation-1",
+			})
+		})
+	}
+}
+
+func TestGRPCStreamInterceptors(t *testing.T) {
+	buf := setTestLogger(t)
+	client := newGRPCTestClient(t)
+
+	ctx := setContextKeyValue(context.Background(), string(CorrelationIDLogKey), "correlation-2")
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "payment"})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+	if values := header.Get(string(CorrelationIDHeaderKey)); len(values) != 1 || values[0] != "correlation-2" {
+		t.Errorf("response metadata %s = %v, want correlation-2", CorrelationIDHeaderKey, values)
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Fatalf("Recv() after cancel error = %v, want Canceled", err)
+	}
+
+	fields := map[string]interface{}{
+		"method":                    "/grpc.health.v1.Health/Watch",
+		"grpc_type":                 "server_stream",
+		"code":                      "Canceled",
+		"status":                    float64(499),
+		"msg_received":              float64(1),
+		"msg_sent":                  float64(1),
+		string(CorrelationIDLogKey): "correlation-2",
+	}
+	assertEntry(t, waitEntry(t, buf, LogTypeGRPC), fields)
+	assertEntry(t, waitEntry(t, buf, LogTypeGRPCClient), fields)
+}
@@ -0,0 +1,210 @@
+package logadapter
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPLoggerConfig config for net/http logger middleware
+type HTTPLoggerConfig struct {
+	Headers    []HeaderMapping // request headers are logged with log keys, if empty use DefaultHeaderMappings
+	IsEnableB3 bool            // parse and emit B3 headers beside W3C traceparent and tracestate headers
+
+	Skipper         func(r *http.Request) bool // skip access log if returns true, context fields are still set
+	ExcludedPaths   []string                   // URL path patterns are not logged, e.g. /healthz, /internal/*, /static/**
+	ExcludedMethods []string                   // HTTP methods are not logged, e.g. OPTIONS
+	RouteLevels     []RouteLevel               // log level of URL paths, first matched is used, default InfoLevel
+
+	StatusLevels  []StatusLevel // log level of response status ranges, override route level, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration // request slower than threshold is logged at Warn with slow=true, 0 to disable
+}
+
+// DefaultHTTPLoggerConfig default config for net/http logger middleware
+var DefaultHTTPLoggerConfig = HTTPLoggerConfig{
+	Headers: DefaultHeaderMappings,
+}
+
+// NewHTTPLoggerMiddleware returns a net/http middleware that logs HTTP requests, same as NewEchoLoggerMiddleware.
+func NewHTTPLoggerMiddleware() func(http.Handler) http.Handler {
+	return NewHTTPLoggerMiddlewareWithConfig(DefaultHTTPLoggerConfig)
+}
+
+// NewHTTPLoggerMiddlewareWithConfig returns a net/http middleware that logs HTTP requests with config.
+func NewHTTPLoggerMiddlewareWithConfig(config HTTPLoggerConfig) func(http.Handler) http.Handler {
+	if len(config.Headers) == 0 {
+		config.Headers = DefaultHeaderMappings
+	}
+	if len(config.StatusLevels) == 0 {
+		config.StatusLevels = DefaultStatusLevels
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := setRequestContext(r.Context(), r.Header, w.Header(), config.Headers, config.IsEnableB3)
+			r = r.WithContext(ctx)
+			if (config.Skipper != nil && config.Skipper(r)) ||
+				isExcludedRequest(r.Method, r.URL.Path, config.ExcludedMethods, config.ExcludedPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			next.ServeHTTP(recorder, r)
+			latency := time.Since(start)
+
+			// * log json format
+			accessLog := accessLogFields(r, realIP(r), recorder.status, recorder.size, latency, nil)
+			defaultLevel := routeLevel(r.Method, r.URL.Path, config.RouteLevels)
+			level, isSlow := accessLogLevel(recorder.status, false, config.StatusLevels, defaultLevel, latency, config.SlowThreshold)
+			if isSlow {
+				accessLog["slow"] = true
+			}
+
+			fields := mergeLogFields(accessLog, GetLogFieldFromContext(ctx))
+			GetLogger().WithFields(fields).Log(logrus.Level(level))
+		})
+	}
+}
+
+// HTTPTransport http.RoundTripper propagates context fields as headers to outbound requests
+// and logs client side latency and status
+type HTTPTransport struct {
+	Base          http.RoundTripper // if null, use http.DefaultTransport
+	Headers       []HeaderMapping   // context fields are set to request headers, if empty use DefaultPropagatedHeaderMappings
+	IsEnableB3    bool              // emit B3 header beside W3C traceparent and tracestate headers
+	StatusLevels  []StatusLevel     // log level of response status ranges, if empty use DefaultStatusLevels
+	SlowThreshold time.Duration     // request slower than threshold is logged at Warn with slow=true, 0 to disable
+}
+
+// NewHTTPTransport returns http.RoundTripper propagates correlation id and request id of context
+func NewHTTPTransport(base http.RoundTripper) *HTTPTransport {
+	return &HTTPTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *HTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	headers := t.Headers
+	if len(headers) == 0 {
+		headers = DefaultPropagatedHeaderMappings
+	}
+	statusLevels := t.StatusLevels
+	if len(statusLevels) == 0 {
+		statusLevels = DefaultStatusLevels
+	}
+
+	ctx := req.Context()
+	// RoundTripper must not modify request
+	req = req.Clone(ctx)
+	for _, mapping := range headers {
+		if req.Header.Get(string(mapping.Header)) != "" {
+			continue
+		}
+		if value := getContextKeyValue(ctx, string(mapping.LogKey)); value != nil {
+			req.Header.Set(string(mapping.Header), fmt.Sprint(value))
+		}
+	}
+	if req.Header.Get(string(TraceParentHeaderKey)) == "" {
+		injectTraceContext(req.Header, trace.SpanContextFromContext(ctx), t.IsEnableB3)
+	}
+
+	start := time.Now()
+	res, err := base.RoundTrip(req)
+	latency := time.Since(start)
+
+	var status int
+	if res != nil {
+		status = res.StatusCode
+	}
+	fields := map[string]interface{}{
+		"type":       LogTypeHTTPClient,
+		"method":     req.Method,
+		"host":       req.URL.Host,
+		"url":        req.URL.Path,
+		"status":     status,
+		"latency":    latency.String(),
+		"latency_ms": latency.Milliseconds(),
+	}
+	if err != nil {
+		fields[logrus.ErrorKey] = err.Error()
+	}
+	level, isSlow := accessLogLevel(status, err != nil, statusLevels, InfoLevel, latency, t.SlowThreshold)
+	if isSlow {
+		fields["slow"] = true
+	}
+	GetLogger().WithFields(mergeLogFields(fields, GetLogFieldFromContext(ctx))).Log(logrus.Level(level))
+
+	return res, err
+}
+
+// responseRecorder records status and size of response
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	size        int64
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// Flush implements http.Flusher
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// Unwrap returns original response writer for http.ResponseController
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// realIP get client IP from X-Forwarded-For, X-Real-IP headers or remote address, same as echo
+func realIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Forwarded-For"); ip != "" {
+		i := strings.IndexAny(ip, ",")
+		if i > 0 {
+			return strings.TrimSpace(ip[:i])
+		}
+		return ip
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
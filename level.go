@@ -0,0 +1,91 @@
+package logadapter
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var logFormatNames = map[LogFormat]string{
+	JSONFormat:       "json",
+	PrettyJSONFormat: "pretty_json",
+	TextFormat:       "text",
+}
+
+// ParseLevel takes a string level and returns the log level, e.g. debug, info, warn, error
+func ParseLevel(level string) (Level, error) {
+	lvl, err := log.ParseLevel(strings.TrimSpace(level))
+	if err != nil {
+		return InfoLevel, fmt.Errorf("%w: %q", ErrInvalidLevel, level)
+	}
+
+	return Level(lvl), nil
+}
+
+// String convert log level to string, e.g. debug, info, warning, error
+func (level Level) String() string {
+	return log.Level(level).String()
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (level Level) MarshalText() ([]byte, error) {
+	if level > TraceLevel {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLevel, level)
+	}
+
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (level *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = lvl
+
+	return nil
+}
+
+// ParseLogFormat takes a string log format and returns the log format, e.g. json, pretty_json, text
+func ParseLogFormat(logFormat string) (LogFormat, error) {
+	name := strings.ToLower(strings.TrimSpace(logFormat))
+	for format, formatName := range logFormatNames {
+		if name == formatName {
+			return format, nil
+		}
+	}
+
+	return JSONFormat, fmt.Errorf("%w: %q", ErrInvalidLogFormat, logFormat)
+}
+
+// String convert log format to string, e.g. json, pretty_json, text
+func (logFormat LogFormat) String() string {
+	if name, ok := logFormatNames[logFormat]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("LogFormat(%d)", uint32(logFormat))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (logFormat LogFormat) MarshalText() ([]byte, error) {
+	name, ok := logFormatNames[logFormat]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLogFormat, logFormat)
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (logFormat *LogFormat) UnmarshalText(text []byte) error {
+	format, err := ParseLogFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*logFormat = format
+
+	return nil
+}
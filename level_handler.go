@@ -0,0 +1,187 @@
+package logadapter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errFormatWithSinks format is rejected, each sink has its own format
+var errFormatWithSinks = errors.New("format can not be changed, logger writes to sinks with their own format")
+
+// LevelHandler http.Handler to get and change log level, log format and log keys at runtime.
+// GET returns current state, PUT changes fields present in request body. Format is not reported and
+// can not be changed if logger writes to sinks, PUT with format returns 409.
+// Mount it on echo with echo.WrapHandler
+type LevelHandler struct {
+	logger   *Logger
+	mu       sync.Mutex
+	override *levelOverride
+}
+
+// LevelState state of LevelHandler, PUT request body and GET response body
+type LevelState struct {
+	Level    *Level         `json:"level,omitempty"`
+	Format   *LogFormat     `json:"format,omitempty"`
+	LogKeys  []LogKey       `json:"log_keys,omitempty"`
+	TTL      string         `json:"ttl,omitempty"` // PUT only, level is reverted after TTL, e.g. 10m
+	Override *LevelOverride `json:"override,omitempty"`
+}
+
+// LevelOverride temporary level override, level is reverted to previous level at expires time
+type LevelOverride struct {
+	Level         Level     `json:"level"`
+	PreviousLevel Level     `json:"previous_level"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type levelOverride struct {
+	LevelOverride
+	timer *time.Timer
+}
+
+// NewLevelHandler returns http.Handler of singleton logger
+func NewLevelHandler() *LevelHandler {
+	return NewLevelHandlerWithLogger(GetLogger())
+}
+
+// NewLevelHandlerWithLogger returns http.Handler of logger
+func NewLevelHandlerWithLogger(logger *Logger) *LevelHandler {
+	return &LevelHandler{logger: logger}
+}
+
+// ServeHTTP implements http.Handler
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeLevelJSON(w, http.StatusOK, h.state())
+
+	case http.MethodPut:
+		var state LevelState
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&state); err != nil {
+			writeLevelJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		if err := h.apply(state); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, errFormatWithSinks) {
+				status = http.StatusConflict
+			}
+			writeLevelJSON(w, status, map[string]string{"error": err.Error()})
+			return
+		}
+		writeLevelJSON(w, http.StatusOK, h.state())
+
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeLevelJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+	}
+}
+
+// SetLevelWithTTL set log level, level is reverted to current level after ttl
+func (h *LevelHandler) SetLevelWithTTL(level Level, ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.setLevel(level, ttl)
+}
+
+func (h *LevelHandler) state() LevelState {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	level := h.logger.GetLogLevel()
+	state := LevelState{
+		Level:   &level,
+		LogKeys: h.logger.GetLogKeys(),
+	}
+	if !h.logger.hasSinks() {
+		format := h.logger.GetFormatter()
+		state.Format = &format
+	}
+	if h.override != nil {
+		override := h.override.LevelOverride
+		state.Override = &override
+	}
+
+	return state
+}
+
+// apply validate state and apply it, nothing is changed if state is invalid
+func (h *LevelHandler) apply(state LevelState) error {
+	var ttl time.Duration
+	if len(state.TTL) > 0 {
+		var err error
+		ttl, err = time.ParseDuration(state.TTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid ttl: %q", state.TTL)
+		}
+		if state.Level == nil {
+			return fmt.Errorf("ttl requires level")
+		}
+	}
+	if state.Format != nil && h.logger.hasSinks() {
+		return errFormatWithSinks
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if state.Format != nil && *state.Format != h.logger.GetFormatter() {
+		h.logger.SetFormatter(*state.Format)
+	}
+	if state.LogKeys != nil {
+		h.logger.SetLogKeys(state.LogKeys)
+	}
+	if state.Level != nil {
+		h.setLevel(*state.Level, ttl)
+	}
+
+	return nil
+}
+
+// setLevel set log level, cancel current override. If ttl > 0, level is reverted after ttl
+func (h *LevelHandler) setLevel(level Level, ttl time.Duration) {
+	previousLevel := h.logger.GetLogLevel()
+	if h.override != nil {
+		h.override.timer.Stop()
+		// keep level before first override
+		previousLevel = h.override.PreviousLevel
+		h.override = nil
+	}
+	h.logger.SetLevel(level)
+	if ttl <= 0 {
+		return
+	}
+
+	override := &levelOverride{
+		LevelOverride: LevelOverride{
+			Level:         level,
+			PreviousLevel: previousLevel,
+			ExpiresAt:     time.Now().Add(ttl),
+		},
+	}
+	override.timer = time.AfterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// override is replaced or cancelled
+		if h.override != override {
+			return
+		}
+		h.logger.SetLevel(override.PreviousLevel)
+		h.override = nil
+	})
+	h.override = override
+}
+
+func writeLevelJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,72 @@
+package logadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveLevelHandler(t *testing.T, handler http.Handler, method, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, "/admin/log", strings.NewReader(body)))
+	var response map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("unmarshal response %q: %v", recorder.Body.String(), err)
+	}
+
+	return recorder.Code, response
+}
+
+func TestLevelHandlerFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		logger     *Logger
+		status     int
+		format     interface{}
+		wantFormat LogFormat
+	}{
+		{
+			name:       "output",
+			logger:     NewWithConfig(&Config{LogLevel: InfoLevel, LogFormat: JSONFormat}),
+			status:     http.StatusOK,
+			format:     "text",
+			wantFormat: TextFormat,
+		},
+		{
+			name:       "sinks",
+			logger:     newTestLogger(&bytes.Buffer{}),
+			status:     http.StatusConflict,
+			wantFormat: JSONFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewLevelHandlerWithLogger(tt.logger)
+
+			status, response := serveLevelHandler(t, handler, http.MethodPut, `{"level":"warn","format":"text"}`)
+			if status != tt.status {
+				t.Fatalf("status = %d, want %d, response %v", status, tt.status, response)
+			}
+			if status == http.StatusOK && response["format"] != tt.format {
+				t.Errorf("format = %v, want %v", response["format"], tt.format)
+			}
+			if got := tt.logger.GetFormatter(); got != tt.wantFormat {
+				t.Errorf("GetFormatter() = %v, want %v", got, tt.wantFormat)
+			}
+			// rejected request changes nothing
+			if level := tt.logger.GetLogLevel(); (level == WarnLevel) != (tt.status == http.StatusOK) {
+				t.Errorf("level = %v after status %d", level, status)
+			}
+
+			_, response = serveLevelHandler(t, handler, http.MethodGet, "")
+			if response["format"] != tt.format {
+				t.Errorf("GET format = %v, want %v", response["format"], tt.format)
+			}
+		})
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -24,38 +28,82 @@ type LogFormat uint32
 // Level log level
 type Level uint32
 
+// RotationInterval interval of log file rotation
+type RotationInterval uint32
+
+// RedactStrategy strategy to replace sensitive value
+type RedactStrategy uint32
+
+// OverflowPolicy policy when async buffer is full
+type OverflowPolicy uint32
+
 // Config config instance log
 type Config struct {
 	IsUseLogFile    bool        // set true if write to file
 	FileConfig      *FileConfig // ignore if IsUseLogFile = false, set null if use default log file config
 	LogLevel        Level
 	LogFormat       LogFormat
-	TimestampFormat string // if empty, use default timestamp format
+	TimestampFormat string                 // if empty, use default timestamp format
+	Async           *AsyncConfig           // set null if write log synchronously
+	Sinks           []Sink                 // if not empty, write log to sinks, IsUseLogFile and FileConfig are ignored
+	Redaction       *RedactionConfig       // set null if not redact sensitive fields
+	LevelSpec       string                 // levels of named loggers, e.g. payment=debug,gorm=warn,*=info
+	DefaultFields   map[string]interface{} // fields are added to all log entries
+	IgnoredPaths    []string               // ignored paths in stack trace field
+	ConsoleWriter   io.Writer              // console output, also written with log file. If null, use os.Stdout
 }
 
 // FileConfig config for write log to file
 type FileConfig struct {
-	Filename       string
-	MaxSize        int // megabytes
-	MaxBackups     int // number of log files
-	MaxAge         int // days
-	IsCompress     bool
-	IsUseLocalTime bool
+	Filename         string
+	MaxSize          int // megabytes
+	MaxBackups       int // number of log files
+	MaxAge           int // days
+	IsCompress       bool
+	IsUseLocalTime   bool
+	RotationInterval RotationInterval // rotate log file at wall-clock interval, RotateNone if rotate by MaxSize only
+	FilenamePattern  string           // time layout of file name, e.g. logs/log_2006-01-02.log, re-evaluated at each rotation. If empty, use Filename
 }
 
-// Logger instance
+// AsyncConfig config for write log asynchronously
+type AsyncConfig struct {
+	BufferSize     int            // number of log entries, default 1024
+	OverflowPolicy OverflowPolicy // policy when buffer is full, default OverflowBlock
+	DropLevel      Level          // with OverflowDropBelowLevel, drop log entries below this level, block others
+	ReportInterval time.Duration  // interval of logging number of dropped entries, default 1 minute
+}
+
+// Logger instance, safe for concurrent use
 type Logger struct {
 	*log.Logger
+	mu              sync.Mutex // guards output and format, lock parent before its named loggers
+	writeMu         sync.Mutex // serializes writes of root logger and its named loggers to shared outputs
 	logFormat       LogFormat
 	timestampFormat string
-	logKeys         []LogKey
-	ignoredPaths    []string
-}
-
-var l *Logger
+	logKeys         logKeySet                // log keys of root logger, used by named loggers
+	ignoredPaths    atomic.Pointer[[]string] // copied on write, read by getCaller without lock
+	formatter       log.Formatter            // formatter of log format, before wrapped by default fields and async output
+	defaultFields   map[string]interface{}
+	asyncConfig     *AsyncConfig
+	asyncOutput     *asyncWriter
+	fileOutput      io.Closer // log file of SetLogFileWithConfig, closed when output is replaced
+	consoleOutput   io.Writer // console writer of SetLogConsole and SetLogFileWithConfig, os.Stdout if null
+	sinks           []sinkOutput
+	redactor        *redactor
+
+	name       string // full name of named logger, e.g. payment.db
+	parent     *Logger
+	children   map[string]*Logger
+	childrenMu sync.Mutex
+	isLevelSet atomic.Bool // level is set explicitly, otherwise inherited from parent
+	levelRules atomic.Pointer[[]levelRule]
+}
+
+// l singleton logger, used by package level functions
+var l atomic.Pointer[Logger]
 
 func init() {
-	l = New()
+	l.Store(New())
 	sourceDir()
 }
 
@@ -74,82 +122,189 @@ func (cl customFormat) Format(entry *log.Entry) ([]byte, error) {
 // SetIgnoredPaths for ignored path in stack trace field.
 // By default logadapter print stacktrace with log has lever higher than WARN, always print stack trace with gorm_adapter
 // Not to print file path has "runtime/" in stack trace. We use logadapter.UpdateIgnoredPaths([]string{"runtime/"})
-func SetIgnoredPaths(paths []string) { l.SetIgnoredPaths(paths) }
+func SetIgnoredPaths(paths []string) { GetLogger().SetIgnoredPaths(paths) }
 
 // SetIgnoredPaths for ignored path in stack trace field.
 // By default logadapter print stacktrace with log has lever higher than WARN, always print stack trace with gorm_adapter
 // Not to print file path has "runtime/" in stack trace. We use logadapter.UpdateIgnoredPaths([]string{"runtime/"})
 func (l *Logger) SetIgnoredPaths(paths []string) {
-	l.ignoredPaths = paths
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	paths = append([]string(nil), paths...)
+	l.ignoredPaths.Store(&paths)
+	l.updateChildren()
 }
 
 // SetFormatter set logger formatter
-func SetFormatter(logFormat LogFormat) { l.SetFormatter(logFormat) }
+func SetFormatter(logFormat LogFormat) { GetLogger().SetFormatter(logFormat) }
 
-// SetFormatter set logger formatter
+// SetFormatter set logger formatter, format of sinks is replaced too
 func (l *Logger) SetFormatter(logFormat LogFormat) {
-	switch logFormat {
-	case JSONFormat:
-		l.Logger.SetFormatter(&log.JSONFormatter{TimestampFormat: DefaultTimestampFormat})
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-	case PrettyJSONFormat:
-		l.Logger.SetFormatter(&log.JSONFormatter{PrettyPrint: true, TimestampFormat: DefaultTimestampFormat})
+	l.setLogFormat(logFormat)
+}
 
-	default:
-		l.Logger.SetFormatter(&log.TextFormatter{TimestampFormat: DefaultTimestampFormat})
+// setLogFormat set log format, l.mu must be held
+func (l *Logger) setLogFormat(logFormat LogFormat) {
+	timestampFormat := l.timestampFormat
+	if len(timestampFormat) == 0 {
+		timestampFormat = DefaultTimestampFormat
 	}
-
 	l.logFormat = logFormat
+	l.setSinkFormat(logFormat, timestampFormat)
+	l.setFormatter(newFormatter(logFormat, timestampFormat))
 }
 
 // GetFormatter get logger formatter
-func GetFormatter() LogFormat { return l.logFormat }
+func GetFormatter() LogFormat { return GetLogger().GetFormatter() }
 
-// SetTimestampFormat set timestamp format
-func SetTimestampFormat(timestampFormat string) { l.SetTimestampFormat(timestampFormat) }
+// GetFormatter get logger formatter
+func (l *Logger) GetFormatter() LogFormat {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
-// SetTimestampFormat set timestamp format
-func (l *Logger) SetTimestampFormat(timestampFormat string) {
-	switch l.logFormat {
-	case JSONFormat:
-		l.Logger.SetFormatter(&log.JSONFormatter{TimestampFormat: timestampFormat})
+	return l.logFormat
+}
 
-	case PrettyJSONFormat:
-		l.Logger.SetFormatter(&log.JSONFormatter{PrettyPrint: true, TimestampFormat: timestampFormat})
+// SetTimestampFormat set timestamp format
+func SetTimestampFormat(timestampFormat string) { GetLogger().SetTimestampFormat(timestampFormat) }
 
-	default:
-		l.Logger.SetFormatter(&log.TextFormatter{TimestampFormat: timestampFormat})
-	}
+// SetTimestampFormat set timestamp format, sinks use format of logger after that
+func (l *Logger) SetTimestampFormat(timestampFormat string) {
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
 
 	l.timestampFormat = timestampFormat
+	l.setSinkFormat(l.logFormat, timestampFormat)
+	l.setFormatter(newFormatter(l.logFormat, timestampFormat))
 }
 
 // GetTimestampFormat get timestamp format
-func GetTimestampFormat() string { return l.timestampFormat }
+func GetTimestampFormat() string { return GetLogger().GetTimestampFormat() }
+
+// GetTimestampFormat get timestamp format
+func (l *Logger) GetTimestampFormat() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.timestampFormat
+}
 
 // SetDefaultFields set default fields for all log
-func SetDefaultFields(fields map[string]interface{}) { l.SetDefaultFields(fields) }
+func SetDefaultFields(fields map[string]interface{}) { GetLogger().SetDefaultFields(fields) }
 
 // SetDefaultFields set default fields for all log
 func (l *Logger) SetDefaultFields(fields map[string]interface{}) {
+	l = l.root()
 	if len(fields) > 0 {
-		l.Logger.SetFormatter(customFormat{
-			defaultFields: fields,
-			formatter:     l.Formatter,
-		})
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.defaultFields = cloneLogFields(fields)
+		l.setFormatter(l.formatter)
+	}
+}
+
+// setFormatter set formatter, wrapped by sinks or async output, redaction and default fields. l.mu must be held
+func (l *Logger) setFormatter(formatter log.Formatter) {
+	l.formatter = formatter
+	switch {
+	case len(l.sinks) > 0:
+		formatter = sinkFormat(l.sinks)
+	case l.asyncOutput != nil:
+		formatter = outputFormat{
+			formatter: formatter,
+			output:    l.asyncOutput,
+		}
+	}
+	if l.redactor != nil {
+		formatter = redactFormat{
+			redactor:  l.redactor,
+			formatter: formatter,
+		}
+	}
+	if len(l.defaultFields) > 0 {
+		formatter = customFormat{
+			defaultFields: l.defaultFields,
+			formatter:     formatter,
+		}
+	}
+	l.Logger.SetFormatter(formatter)
+	l.updateChildren()
+}
+
+// setOutput set output, wrapped by async writer if async config is set. l.mu must be held
+func (l *Logger) setOutput(output io.Writer) {
+	previous := l.asyncWriters()
+	previousFile := l.fileOutput
+	l.sinks = nil
+	l.asyncOutput = nil
+	l.fileOutput = nil
+	output = lockedWriter{mu: &l.root().writeMu, Writer: output}
+	if l.asyncConfig != nil {
+		l.asyncOutput = newAsyncWriter(output, l.asyncConfig, l.reportDroppedEntries)
+		output = l.asyncOutput
+	}
+	l.Logger.SetOutput(output)
+	l.setFormatter(l.formatter)
+	closeAsyncWriters(previous)
+	closeFileOutput(previousFile)
+}
+
+// closeFileOutput close replaced log file after buffered entries are written to it
+func closeFileOutput(file io.Closer) {
+	if file == nil {
+		return
+	}
+	if err := file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to close log file, %v\n", err)
 	}
 }
 
 // SetLogFile set log file, log file will be storaged in logs folder
-func SetLogFile() { l.SetLogFile() }
+func SetLogFile() { GetLogger().SetLogFile() }
 
-// SetLogFileWithConfig set log file with file config
+// SetLogFileWithConfig set log file with file config.
+// If default log file config is used and logs folder can not be created, log is written to console
 func (l *Logger) SetLogFileWithConfig(fileConfig *FileConfig) {
+	l = l.root()
 	if fileConfig == nil {
-		fileConfig = getDefaultFileConfig()
+		var err error
+		if fileConfig, err = getDefaultFileConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set log file, %v\n", err)
+			l.SetLogConsole()
+			return
+		}
 	}
 
-	var lumber = &lumberjack.Logger{
+	file := NewFileWriter(fileConfig)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setOutput(io.MultiWriter(l.console(), file))
+	l.fileOutput = file
+}
+
+// NewFileWriter returns writer to log file with file config, set null if use default log file config.
+// If default log file config is used and logs folder can not be created, Write returns the error
+func NewFileWriter(fileConfig *FileConfig) io.WriteCloser {
+	if fileConfig == nil {
+		var err error
+		if fileConfig, err = getDefaultFileConfig(); err != nil {
+			return failedWriter{err: err}
+		}
+	}
+
+	if fileConfig.RotationInterval != RotateNone {
+		return newTimeRotateWriter(fileConfig)
+	}
+
+	return &lumberjack.Logger{
 		Filename:   fileConfig.Filename,
 		MaxSize:    fileConfig.MaxSize,
 		MaxBackups: fileConfig.MaxBackups,
@@ -157,46 +312,153 @@ func (l *Logger) SetLogFileWithConfig(fileConfig *FileConfig) {
 		Compress:   fileConfig.IsCompress,
 		LocalTime:  fileConfig.IsUseLocalTime,
 	}
-	writer := io.MultiWriter(os.Stdout, lumber)
-	l.Logger.SetOutput(writer)
 }
 
 // SetLogFile set log file, log file will be storaged in logs folder
 func (l *Logger) SetLogFile() {
-	fileConfig := getDefaultFileConfig()
-	l.SetLogFileWithConfig(fileConfig)
+	l.SetLogFileWithConfig(nil)
+}
+
+// SetOutput set log output
+func SetOutput(output io.Writer) { GetLogger().SetOutput(output) }
+
+// SetOutput set log output, named loggers write to the same output
+func (l *Logger) SetOutput(output io.Writer) {
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setOutput(output)
 }
 
 // SetLogConsole set log console
-func SetLogConsole() { l.SetLogConsole() }
+func SetLogConsole() { GetLogger().SetLogConsole() }
 
 // SetLogConsole set log console
 func (l *Logger) SetLogConsole() {
-	l.SetOutput(os.Stdout)
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setOutput(l.console())
 }
 
-// SetLevel set log level
-func SetLevel(level Level) { l.SetLevel(level) }
+// console returns console writer of root logger
+func (l *Logger) console() io.Writer {
+	if l.consoleOutput == nil {
+		return os.Stdout
+	}
+
+	return l.consoleOutput
+}
 
 // SetLevel set log level
+func SetLevel(level Level) { GetLogger().SetLevel(level) }
+
+// SetLevel set log level, named loggers without their own level inherit it
 func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setLevel(level)
+}
+
+// setLevel set log level, l.mu must be held
+func (l *Logger) setLevel(level Level) {
+	l.isLevelSet.Store(true)
 	l.Logger.SetLevel(log.Level(level))
+	l.updateChildren()
+}
+
+// GetLogLevel get log level
+func GetLogLevel() Level { return GetLogger().GetLogLevel() }
+
+// GetLogLevel get log level
+func (l *Logger) GetLogLevel() Level {
+	return Level(l.Logger.GetLevel())
+}
+
+// SetLogger set logger instance, safe to call while other goroutines are logging
+func SetLogger(logger *Logger) { l.Store(logger) }
+
+// GetLogger get logger instance
+func GetLogger() *Logger { return l.Load() }
+
+// Flush wait until all buffered log entries are written, only take effect with async config
+func Flush(ctx context.Context) error { return GetLogger().Flush(ctx) }
+
+// Flush wait until all buffered log entries are written, only take effect with async config
+func (l *Logger) Flush(ctx context.Context) error {
+	l = l.root()
+	l.mu.Lock()
+	writers := l.asyncWriters()
+	l.mu.Unlock()
+
+	for _, writer := range writers {
+		if err := writer.Flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close write all buffered log entries and stop background writer, only take effect with async config.
+// Log entries after Close are written synchronously
+func Close() error { return GetLogger().Close() }
+
+// Close write all buffered log entries and stop background writer, only take effect with async config.
+// Log entries after Close are written synchronously
+func (l *Logger) Close() error {
+	l = l.root()
+	l.mu.Lock()
+	writers := l.asyncWriters()
+	l.mu.Unlock()
+
+	closeAsyncWriters(writers)
+	return nil
+}
+
+// DroppedEntries returns number of log entries dropped by async overflow policy
+func (l *Logger) DroppedEntries() uint64 {
+	l = l.root()
+	l.mu.Lock()
+	writers := l.asyncWriters()
+	l.mu.Unlock()
+
+	var dropped uint64
+	for _, writer := range writers {
+		dropped += writer.DroppedEntries()
+	}
+
+	return dropped
 }
 
-// SetLogger set logger instance
-func SetLogger(logger *Logger) { l = logger }
+func (l *Logger) reportDroppedEntries(dropped uint64) {
+	l.WithFields(log.Fields{"type": LogTypeWarn, "dropped": dropped}).Warn("log entries are dropped, async buffer is full")
+}
+
+// getDefaultFileConfig returns default log file config in logs folder of working directory, create folder if not exists
+func getDefaultFileConfig() (*FileConfig, error) {
+	dir, err := getLogDir()
+	if err != nil {
+		return nil, err
+	}
 
-// GetLogger set logger instance
-func GetLogger() *Logger { return l }
+	return newDefaultFileConfig(dir), nil
+}
 
-func getDefaultFileConfig() *FileConfig {
+// newDefaultFileConfig returns default log file config in dir, rotates daily. Folder is not created
+func newDefaultFileConfig(dir string) *FileConfig {
 	return &FileConfig{
-		Filename:       getLogFile(),
-		MaxSize:        10,
-		MaxBackups:     3,
-		MaxAge:         30,
-		IsCompress:     false,
-		IsUseLocalTime: true,
+		Filename:         filepath.Join(dir, fmt.Sprintf("log_%s.log", time.Now().Format("2006-01-02"))),
+		MaxSize:          10,
+		MaxBackups:       3,
+		MaxAge:           30,
+		IsCompress:       false,
+		IsUseLocalTime:   true,
+		RotationInterval: RotateDaily,
+		FilenamePattern:  filepath.Join(dir, "log_2006-01-02.log"),
 	}
 }
 
@@ -211,21 +473,31 @@ func getDefaultConfig() *Config {
 
 // RemoveLogKey remove a log key will not log this key
 func (l *Logger) RemoveLogKey(key string) {
-	for i := 0; i < len(l.logKeys); i++ {
-		if strings.EqualFold(string(l.logKeys[i]), key) {
-			l.logKeys = append(l.logKeys[:i], l.logKeys[i+1:]...)
-		}
-	}
+	l.root().logKeys.remove(LogKey(key))
 }
 
 // RemoveLogKey export remove a log key will not log this key
-func RemoveLogKey(key string) { l.RemoveLogKey(key) }
+func RemoveLogKey(key string) { GetLogger().RemoveLogKey(key) }
+
+// GetLogKeys get log keys are logged from context
+func GetLogKeys() []LogKey { return GetLogger().GetLogKeys() }
+
+// GetLogKeys get log keys are logged from context
+func (l *Logger) GetLogKeys() []LogKey {
+	return append([]LogKey(nil), l.root().logKeys.get()...)
+}
+
+// SetLogKeys set log keys are logged from context, replace current log keys
+func SetLogKeys(keys []LogKey) { GetLogger().SetLogKeys(keys) }
+
+// SetLogKeys set log keys are logged from context, replace current log keys
+func (l *Logger) SetLogKeys(keys []LogKey) {
+	l.root().logKeys.set(keys)
+}
 
 // addLogKey add one more log key
 func (l *Logger) addLogKey(key string) {
-	if !logKeyExists(l.logKeys, LogKey(key)) {
-		l.logKeys = append(l.logKeys, LogKey(key))
-	}
+	l.root().logKeys.add(LogKey(key))
 }
 
 // SetContext set log with context
@@ -235,39 +507,64 @@ func (l *Logger) SetContext(ctx context.Context) *log.Entry {
 
 // SetContext set log with context
 func SetContext(ctx context.Context) *log.Entry {
-	return l.SetContext(ctx)
+	return GetLogger().SetContext(ctx)
 }
 
 // GetLogFieldFromContext gets log field from context for log field
 func GetLogFieldFromContext(ctx context.Context) map[string]interface{} {
-	return l.GetLogFieldFromContext(ctx)
+	return GetLogger().GetLogFieldFromContext(ctx)
 }
 
 // GetLogFieldFromContext gets log field from context for log field
 func (l *Logger) GetLogFieldFromContext(ctx context.Context) map[string]interface{} {
+	logKeys := l.root().logKeys.get()
 	fields := make(map[string]interface{})
-	for _, key := range l.logKeys {
+	for _, key := range logKeys {
 		val := getContextKeyValue(ctx, string(key))
 		if val != nil {
 			fields[string(key)] = val
 		}
 	}
+	for key, val := range getTraceLogFields(ctx) {
+		if logKeyExists(logKeys, LogKey(key)) {
+			fields[key] = val
+		}
+	}
+	for key, val := range getContextFields(ctx) {
+		fields[key] = val
+	}
 
 	return fields
 }
 
-// SetCustomLogField set custom log field for always log this field, return new context
+// WithFields returns new context carries fields, fields are logged with this context and its children only.
+// Fields of parent context are kept, same keys are overridden. Unlike SetCustomLogField, log keys are not changed.
+// Nil ctx is treated as context.Background()
+func WithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextFieldsKey{}, mergeLogFields(getContextFields(ctx), fields))
+}
+
+// SetCustomLogField set custom log field for always log this field, return new context.
+// Log key is added to log keys of logger and looked up in all contexts, use WithFields for fields of one context
 func (l *Logger) SetCustomLogField(ctx context.Context, logKey string, value interface{}) context.Context {
 	l.addLogKey(logKey)
 	return setContextKeyValue(ctx, logKey, value)
 }
 
-// SetCustomLogField set custom log field for always log this field, return new context
+// SetCustomLogField set custom log field for always log this field, return new context.
+// Log key is added to log keys of logger and looked up in all contexts, use WithFields for fields of one context
 func SetCustomLogField(ctx context.Context, logKey string, value interface{}) context.Context {
-	return l.SetCustomLogField(ctx, logKey, value)
+	return GetLogger().SetCustomLogField(ctx, logKey, value)
 }
 
-func (l *Logger) getCaller() string {
+func (l *Logger) getCaller(ignoredPaths ...string) string {
 	var caller string
 	pc := make([]uintptr, 10)
 	n := runtime.Callers(2, pc)
@@ -275,6 +572,9 @@ func (l *Logger) getCaller() string {
 		return caller
 	}
 	pc = pc[:n]
+	if paths := l.ignoredPaths.Load(); paths != nil {
+		ignoredPaths = append(ignoredPaths, *paths...)
+	}
 	frames := runtime.CallersFrames(pc)
 	for {
 		frame, more := frames.Next()
@@ -283,7 +583,7 @@ func (l *Logger) getCaller() string {
 			continue
 		}
 		var isIgnore bool
-		for _, path := range l.ignoredPaths {
+		for _, path := range ignoredPaths {
 			if strings.Contains(frame.File, path) {
 				isIgnore = true
 				continue
@@ -314,19 +614,32 @@ func NewWithConfig(config *Config) *Logger {
 		config = getDefaultConfig()
 	}
 	logger := log.New()
-	l := &Logger{Logger: logger}
+	l := &Logger{
+		Logger:        logger,
+		asyncConfig:   config.Async,
+		redactor:      newRedactor(config.Redaction),
+		consoleOutput: config.ConsoleWriter,
+	}
 	l.logFormat = config.LogFormat
 	l.SetFormatter(config.LogFormat)
 	if len(config.TimestampFormat) > 0 {
 		l.SetTimestampFormat(config.TimestampFormat)
 	}
-	if config.IsUseLogFile == true {
+	if len(config.Sinks) > 0 {
+		l.SetSinks(config.Sinks)
+	} else if config.IsUseLogFile == true {
 		l.SetLogFileWithConfig(config.FileConfig)
 	} else {
 		l.SetLogConsole()
 	}
 	l.SetLevel(config.LogLevel)
-	l.logKeys = DefaultLogKeys
+	l.SetDefaultFields(config.DefaultFields)
+	l.SetIgnoredPaths(config.IgnoredPaths)
+	if len(config.LevelSpec) > 0 {
+		if err := l.SetLevelSpec(config.LevelSpec); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to set level spec, %v\n", err)
+		}
+	}
 
 	return l
 }
@@ -347,85 +660,92 @@ func New() *Logger {
 		l.SetLogConsole()
 	}
 	l.SetLevel(config.LogLevel)
-	l.logKeys = DefaultLogKeys
 
 	return l
 }
 
 // Trace log with trace level
 func Trace(args ...interface{}) {
-	l.Trace(args...)
+	GetLogger().Trace(args...)
 }
 
 // Debug log with debug level
 func Debug(args ...interface{}) {
-	l.Debug(args...)
+	GetLogger().Debug(args...)
 }
 
 // Info log with info level
 func Info(args ...interface{}) {
-	l.Info(args...)
+	GetLogger().Info(args...)
 }
 
 // Warn log with warn level
 func Warn(args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.WithFields(field).Warn(args...)
 }
 
 // Error log with error level
 func Error(args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.WithFields(field).Error(args...)
 }
 
 // Fatal log with fatal level
 func Fatal(args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.WithFields(field).Fatal(args...)
 }
 
 // Panic log with panic level
 func Panic(args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.WithFields(field).Panic(args...)
 }
 
 // TraceWithContext log with trace level
 func TraceWithContext(ctx context.Context, args ...interface{}) {
-	l.SetContext(ctx).Trace(args...)
+	GetLogger().SetContext(ctx).Trace(args...)
 }
 
 // DebugWithContext log with debug level
 func DebugWithContext(ctx context.Context, args ...interface{}) {
-	l.SetContext(ctx).Debug(args...)
+	GetLogger().SetContext(ctx).Debug(args...)
 }
 
 // InfoWithContext log with info level
 func InfoWithContext(ctx context.Context, args ...interface{}) {
-	l.SetContext(ctx).Info(args...)
+	GetLogger().SetContext(ctx).Info(args...)
 }
 
 // WarnWithContext log with warn level
 func WarnWithContext(ctx context.Context, args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.SetContext(ctx).WithFields(field).Warn(args...)
 }
 
 // ErrorWithContext log with error level
 func ErrorWithContext(ctx context.Context, args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.SetContext(ctx).WithFields(field).Error(args...)
 }
 
 // FatalWithContext log with fatal level
 func FatalWithContext(ctx context.Context, args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.SetContext(ctx).WithFields(field).Fatal(args...)
 }
 
 // PanicWithContext log with panic level
 func PanicWithContext(ctx context.Context, args ...interface{}) {
+	l := GetLogger()
 	field := log.Fields{DefaultSourceField: l.getCaller()}
 	l.SetContext(ctx).WithFields(field).Panic(args...)
 }
@@ -435,6 +755,7 @@ func PanicWithContext(ctx context.Context, args ...interface{}) {
 // content[1] : log type -> string,
 // content[2] : log field -> map[string]interface{}
 func LogWithContext(ctx context.Context, content ...interface{}) {
+	l := GetLogger()
 	var logType string
 	if len(content) > 1 {
 		if value, ok := content[1].(string); ok && value != "" {
@@ -452,20 +773,9 @@ func LogWithContext(ctx context.Context, content ...interface{}) {
 		}
 	}
 
-	switch logType {
-	case LogTypeAPI:
-		l.Logger.WithFields(logFields).Info(content[0])
-	case LogTypeError:
-		field := log.Fields{DefaultSourceField: l.getCaller()}
-		l.Logger.WithFields(mergeLogFields(logFields, field)).Error(content[0])
-	case LogTypeInfo:
-		l.Logger.WithFields(logFields).Info(content[0])
-	case LogTypeWarn:
-		field := log.Fields{DefaultSourceField: l.getCaller()}
-		l.Logger.WithFields(mergeLogFields(logFields, field)).Warn(content[0])
-	case LogTypeRequest, LogTypeResponse:
-		l.Logger.WithFields(logFields).Info(content[0])
-	default:
-		l.Logger.WithFields(logFields).Debug(content[0])
+	level := levelFromLogType(logType)
+	if level <= WarnLevel {
+		logFields = mergeLogFields(logFields, log.Fields{DefaultSourceField: l.getCaller()})
 	}
+	l.Logger.WithFields(logFields).Log(log.Level(level), content[0])
 }
@@ -0,0 +1,301 @@
+package logadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// newTestLogger returns logger writes JSON entries to writer
+func newTestLogger(w io.Writer) *Logger {
+	return NewWithConfig(&Config{
+		LogLevel: TraceLevel,
+		Sinks:    []Sink{{Writer: w, LogLevel: TraceLevel, LogFormat: JSONFormat}},
+	})
+}
+
+// syncBuffer buffer safe for concurrent write and read
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+// entries returns decoded JSON entries written to buffer
+func (b *syncBuffer) entries(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b.buf.Bytes()))
+	for decoder.More() {
+		var entry map[string]interface{}
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("decode entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestGetLogFieldFromContextTrace(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	tracer := noop.NewTracerProvider().Tracer("logadapter")
+
+	validCtx, span := tracer.Start(trace.ContextWithSpanContext(context.Background(), spanContext), "valid")
+	defer span.End()
+	invalidCtx, span := tracer.Start(context.Background(), "invalid")
+	defer span.End()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		fields map[string]interface{}
+	}{
+		{
+			name: "valid span context",
+			ctx:  validCtx,
+			fields: map[string]interface{}{
+				string(TraceIDLogKey):    "4bf92f3577b34da6a3ce929d0e0e4736",
+				string(SpanIDLogKey):     "00f067aa0ba902b7",
+				string(TraceFlagsLogKey): "01",
+			},
+		},
+		{
+			name:   "invalid span context",
+			ctx:    invalidCtx,
+			fields: map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := newTestLogger(&buf)
+
+			fields := logger.GetLogFieldFromContext(tt.ctx)
+			if len(fields) != len(tt.fields) {
+				t.Fatalf("GetLogFieldFromContext() = %v, want %v", fields, tt.fields)
+			}
+			for key, value := range tt.fields {
+				if fields[key] != value {
+					t.Errorf("GetLogFieldFromContext()[%s] = %v, want %v", key, fields[key], value)
+				}
+			}
+
+			logger.SetContext(tt.ctx).Info("message")
+			var entry map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("unmarshal entry %q: %v", buf.String(), err)
+			}
+			for _, key := range []LogKey{TraceIDLogKey, SpanIDLogKey, TraceFlagsLogKey} {
+				value, ok := entry[string(key)]
+				if want, isWanted := tt.fields[string(key)]; isWanted != ok || value != want {
+					t.Errorf("SetContext() entry[%s] = %v, want %v", key, value, want)
+				}
+			}
+		})
+	}
+}
+
+// overlapWriter counts writes overlapped with another write
+type overlapWriter struct {
+	writing  atomic.Int32
+	overlaps atomic.Int32
+	lines    atomic.Int32
+}
+
+func (w *overlapWriter) Write(p []byte) (int, error) {
+	if w.writing.Add(1) > 1 {
+		w.overlaps.Add(1)
+	}
+	time.Sleep(time.Microsecond)
+	w.lines.Add(int32(bytes.Count(p, []byte("\n"))))
+	w.writing.Add(-1)
+
+	return len(p), nil
+}
+
+func TestNamedLoggerSharedOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		newLogger func(w io.Writer) *Logger
+	}{
+		{
+			name: "output",
+			newLogger: func(w io.Writer) *Logger {
+				logger := NewWithConfig(&Config{LogLevel: DebugLevel})
+				logger.SetOutput(w)
+				return logger
+			},
+		},
+		{
+			name:      "sinks",
+			newLogger: newTestLogger,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &overlapWriter{}
+			logger := tt.newLogger(w)
+			loggers := []*Logger{logger, logger.Named("gorm"), logger.Named("echo").Named("db")}
+
+			var wg sync.WaitGroup
+			for _, named := range loggers {
+				wg.Add(1)
+				go func(named *Logger) {
+					defer wg.Done()
+					for i := 0; i < 200; i++ {
+						named.Info("message")
+					}
+				}(named)
+			}
+			wg.Wait()
+
+			if overlaps := w.overlaps.Load(); overlaps > 0 {
+				t.Errorf("%d writes are overlapped", overlaps)
+			}
+			if lines := w.lines.Load(); lines != 600 {
+				t.Errorf("%d entries are written, want 600", lines)
+			}
+		})
+	}
+}
+
+func TestSetLogFileWithConfigClosesPreviousFile(t *testing.T) {
+	countFiles := func() int {
+		files, err := os.ReadDir("/proc/self/fd")
+		if err != nil {
+			t.Skipf("open files are not countable: %v", err)
+		}
+		return len(files)
+	}
+
+	dir := t.TempDir()
+	logger := NewWithConfig(&Config{LogLevel: InfoLevel})
+	logger.SetLogFileWithConfig(&FileConfig{Filename: filepath.Join(dir, "0.log")})
+	logger.Info("open file")
+	before := countFiles()
+	for i := 1; i <= 6; i++ {
+		logger.SetLogFileWithConfig(&FileConfig{Filename: filepath.Join(dir, fmt.Sprintf("%d.log", i))})
+		logger.Info("open file")
+	}
+	if after := countFiles(); after != before {
+		t.Errorf("open files = %d after 6 log file changes, want %d", after, before)
+	}
+
+	logger.SetLogConsole()
+	if after := countFiles(); after != before-1 {
+		t.Errorf("open files = %d after log console is set, want %d", after, before-1)
+	}
+}
+
+func TestConcurrentLoggingAndMutation(t *testing.T) {
+	previous := GetLogger()
+	t.Cleanup(func() { SetLogger(previous) })
+
+	buf := &syncBuffer{}
+	loggers := []*Logger{newTestLogger(buf), NewWithConfig(&Config{LogLevel: DebugLevel})}
+	loggers[1].SetOutput(buf)
+	SetLogger(loggers[0])
+
+	const iterations = 200
+	var wg sync.WaitGroup
+	run := func(f func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				f(i)
+			}
+		}()
+	}
+
+	// logging
+	for g := 0; g < 4; g++ {
+		run(func(i int) {
+			ctx := SetCustomLogField(context.Background(), fmt.Sprintf("key_%d", i%10), i)
+			ctx = WithFields(ctx, map[string]interface{}{"iteration": i})
+			InfoWithContext(ctx, "info message")
+			WarnWithContext(ctx, "warn message")
+			Error("error message")
+			With(ctx).Str("field", "value").Info("event message")
+			GetLogger().Named("payment").Info("named message")
+		})
+	}
+	// mutation
+	run(func(i int) { SetIgnoredPaths([]string{fmt.Sprintf("path_%d/", i)}) })
+	run(func(i int) { RemoveLogKey(fmt.Sprintf("key_%d", i%10)) })
+	run(func(i int) { SetLogger(loggers[i%len(loggers)]) })
+	run(func(i int) { SetFormatter(LogFormat(i % len(logFormatNames))) })
+	run(func(i int) { SetLevel(Level(i%2) + InfoLevel) })
+	run(func(i int) {
+		_ = GetLogKeys()
+		_ = GetFormatter()
+		_ = GetLogLevel()
+	})
+	wg.Wait()
+
+	if buf.buf.Len() == 0 {
+		t.Error("no entry is written")
+	}
+}
+
+func TestZeroValueLogger(t *testing.T) {
+	logger := &Logger{Logger: logrus.New()}
+	ctx := setContextKeyValue(context.Background(), CorrelationIDLogKey, "correlation")
+	if fields := logger.GetLogFieldFromContext(ctx); fields[string(CorrelationIDLogKey)] != "correlation" {
+		t.Errorf("GetLogFieldFromContext() = %v", fields)
+	}
+	if len(logger.GetLogKeys()) != len(DefaultLogKeys) {
+		t.Errorf("GetLogKeys() = %v, want %v", logger.GetLogKeys(), DefaultLogKeys)
+	}
+
+	buf := &syncBuffer{}
+	logger.SetOutput(buf)
+	logger.SetFormatter(JSONFormat)
+	logger.SetTimestampFormat(time.RFC3339)
+	logger.SetDefaultFields(map[string]interface{}{"app": "test"})
+	logger.RemoveLogKey(string(UserInfoLogKey))
+	logger.SetLevel(DebugLevel)
+	logger.Named("payment").SetContext(ctx).Debug("named message")
+
+	if len(logger.GetLogKeys()) != len(DefaultLogKeys)-1 || !logKeyExists(DefaultLogKeys, UserInfoLogKey) {
+		t.Errorf("GetLogKeys() = %v, DefaultLogKeys = %v", logger.GetLogKeys(), DefaultLogKeys)
+	}
+
+	entries := buf.entries(t)
+	if len(entries) != 1 {
+		t.Fatalf("%d entries are written, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry["msg"] != "named message" || entry["app"] != "test" || entry[DefaultModuleField] != "payment" ||
+		entry[string(CorrelationIDLogKey)] != "correlation" {
+		t.Errorf("entry = %v", entry)
+	}
+}
@@ -0,0 +1,252 @@
+package logadapter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// levelRule level of named loggers matched pattern
+type levelRule struct {
+	pattern string
+	level   Level
+}
+
+// moduleFormat add module field of named logger before formatting
+type moduleFormat struct {
+	module    string
+	formatter log.Formatter
+}
+
+func (mf moduleFormat) Format(entry *log.Entry) ([]byte, error) {
+	entry.Data[DefaultModuleField] = mf.module
+
+	return mf.formatter.Format(entry)
+}
+
+// Named returns named logger of module, log entries have module field.
+// Output, format and ignored paths are inherited from parent, level is inherited until SetLevel is called
+func Named(name string) *Logger { return GetLogger().Named(name) }
+
+// Named returns named logger of module, log entries have module field, e.g. l.Named("payment").Named("db") has module payment.db.
+// Output, format and ignored paths are inherited from parent, level is inherited until SetLevel is called.
+// Output, format, default fields, redaction and sinks are owned by root logger, setting them on named logger changes root logger
+func (l *Logger) Named(name string) *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.childrenMu.Lock()
+	defer l.childrenMu.Unlock()
+
+	if child, ok := l.children[name]; ok {
+		return child
+	}
+
+	fullName := name
+	if len(l.name) > 0 {
+		fullName = l.name + "." + name
+	}
+	logger := log.New()
+	logger.Hooks = l.Logger.Hooks
+	logger.ExitFunc = l.Logger.ExitFunc
+	logger.ReportCaller = l.Logger.ReportCaller
+
+	child := &Logger{
+		Logger: logger,
+		name:   fullName,
+		parent: l,
+	}
+	child.mu.Lock()
+	if level, ok := l.ruleLevel(fullName); ok {
+		child.isLevelSet.Store(true)
+		child.Logger.SetLevel(log.Level(level))
+	}
+	child.inherit(l)
+	child.mu.Unlock()
+
+	if l.children == nil {
+		l.children = make(map[string]*Logger)
+	}
+	l.children[name] = child
+
+	return child
+}
+
+// root returns root logger of named logger, output and format of named loggers are owned by root logger
+func (l *Logger) root() *Logger {
+	for l.parent != nil {
+		l = l.parent
+	}
+
+	return l
+}
+
+// Name returns full name of named logger, empty if logger is not named
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// InheritLevel remove level set by SetLevel, inherit level of parent
+func (l *Logger) InheritLevel() {
+	if l.parent == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.isLevelSet.Store(false)
+	l.Logger.SetLevel(l.parent.Logger.GetLevel())
+	l.updateChildren()
+}
+
+// SetLevelSpec set levels of logger and its named loggers, e.g. payment=debug,gorm=warn,*=info
+func SetLevelSpec(spec string) error { return GetLogger().SetLevelSpec(spec) }
+
+// SetLevelSpec set levels of logger and its named loggers, e.g. payment=debug,gorm=warn,*=info.
+// Pattern is matched with full name of named logger, e.g. payment.*, level without pattern or with * is level of logger.
+// Named loggers are not matched inherit level of parent
+func (l *Logger) SetLevelSpec(spec string) error {
+	rules, err := parseLevelSpec(spec)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setLevelRules(rules)
+	l.updateChildren()
+
+	return nil
+}
+
+// setLevelRules set level rules of named loggers, rule with pattern * is level of logger.
+// l.mu must be held, named loggers without matched rule are updated by updateChildren
+func (l *Logger) setLevelRules(rules []levelRule) {
+	var namedRules []levelRule
+	for _, rule := range rules {
+		if rule.pattern == "*" {
+			l.isLevelSet.Store(true)
+			l.Logger.SetLevel(log.Level(rule.level))
+			continue
+		}
+		namedRules = append(namedRules, rule)
+	}
+
+	l.levelRules.Store(&namedRules)
+	l.applyLevelRules(namedRules)
+}
+
+// SetLevelSpecFromEnv set level spec from DefaultLevelSpecEnv environment variable, do nothing if it's empty
+func SetLevelSpecFromEnv() error { return GetLogger().SetLevelSpecFromEnv() }
+
+// SetLevelSpecFromEnv set level spec from DefaultLevelSpecEnv environment variable, do nothing if it's empty
+func (l *Logger) SetLevelSpecFromEnv() error {
+	spec := os.Getenv(DefaultLevelSpecEnv)
+	if len(spec) == 0 {
+		return nil
+	}
+
+	return l.SetLevelSpec(spec)
+}
+
+// inherit copy output, format and level of parent, then update its named loggers.
+// parent.mu and l.mu must be held
+func (l *Logger) inherit(parent *Logger) {
+	l.logFormat = parent.logFormat
+	l.timestampFormat = parent.timestampFormat
+	l.ignoredPaths.Store(parent.ignoredPaths.Load())
+	l.formatter = parent.formatter
+
+	formatter := parent.Logger.Formatter
+	if mf, ok := formatter.(moduleFormat); ok {
+		formatter = mf.formatter
+	}
+	l.Logger.SetFormatter(moduleFormat{module: l.name, formatter: formatter})
+	l.Logger.SetOutput(parent.Logger.Out)
+	if !l.isLevelSet.Load() {
+		l.Logger.SetLevel(parent.Logger.GetLevel())
+	}
+	l.updateChildren()
+}
+
+// updateChildren update named loggers after output, format or level is changed, l.mu must be held
+func (l *Logger) updateChildren() {
+	l.childrenMu.Lock()
+	defer l.childrenMu.Unlock()
+
+	for _, child := range l.children {
+		child.mu.Lock()
+		child.inherit(l)
+		child.mu.Unlock()
+	}
+}
+
+// applyLevelRules set level of matched named loggers, others inherit level of parent. l.mu must be held
+func (l *Logger) applyLevelRules(rules []levelRule) {
+	l.childrenMu.Lock()
+	defer l.childrenMu.Unlock()
+
+	for _, child := range l.children {
+		child.mu.Lock()
+		if level, ok := matchLevelRules(rules, child.name); ok {
+			child.isLevelSet.Store(true)
+			child.Logger.SetLevel(log.Level(level))
+		} else {
+			child.isLevelSet.Store(false)
+		}
+		child.applyLevelRules(rules)
+		child.mu.Unlock()
+	}
+}
+
+// ruleLevel get level of named logger from level spec of logger and its parents
+func (l *Logger) ruleLevel(name string) (Level, bool) {
+	for logger := l; logger != nil; logger = logger.parent {
+		if rules := logger.levelRules.Load(); rules != nil {
+			if level, ok := matchLevelRules(*rules, name); ok {
+				return level, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func matchLevelRules(rules []levelRule, name string) (Level, bool) {
+	for _, rule := range rules {
+		if matched, _ := path.Match(rule.pattern, name); matched || rule.pattern == name {
+			return rule.level, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseLevelSpec parses level spec, e.g. payment=debug,gorm=warn,*=info
+func parseLevelSpec(spec string) ([]levelRule, error) {
+	var rules []levelRule
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+
+		pattern, levelText, ok := strings.Cut(item, "=")
+		if !ok {
+			pattern, levelText = "*", item
+		}
+		pattern = strings.TrimSpace(pattern)
+		if _, err := path.Match(pattern, ""); err != nil || len(pattern) == 0 {
+			return nil, fmt.Errorf("%w %q: invalid pattern", ErrInvalidLevelSpec, item)
+		}
+		level, err := ParseLevel(levelText)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidLevelSpec, item, err)
+		}
+		rules = append(rules, levelRule{pattern: pattern, level: level})
+	}
+
+	return rules, nil
+}
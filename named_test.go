@@ -0,0 +1,103 @@
+package logadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestNamedLoggerMutators(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte
+	}{
+		{name: "SetFormatter", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			named.SetFormatter(JSONFormat)
+			return nil
+		}},
+		{name: "SetTimestampFormat", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			named.SetTimestampFormat("2006")
+			return nil
+		}},
+		{name: "SetDefaultFields", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			named.SetDefaultFields(map[string]interface{}{"app": "test"})
+			return nil
+		}},
+		{name: "SetOutput", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			var output bytes.Buffer
+			named.SetOutput(&output)
+			return output.Bytes
+		}},
+		{name: "SetSinks", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			var output bytes.Buffer
+			named.SetSinks([]Sink{{Writer: &output, LogLevel: InfoLevel, LogFormat: JSONFormat}})
+			return output.Bytes
+		}},
+		{name: "SetLogFileWithConfig", mutate: func(t *testing.T, named *Logger, buf *syncBuffer) func() []byte {
+			filename := filepath.Join(t.TempDir(), "named.log")
+			named.SetLogFileWithConfig(&FileConfig{Filename: filename})
+			return func() []byte {
+				named.root().SetOutput(io.Discard)
+				data, err := os.ReadFile(filename)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return data
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &syncBuffer{}
+			logger := NewWithConfig(&Config{
+				LogLevel: InfoLevel,
+				Sinks:    []Sink{{Writer: buf, LogLevel: InfoLevel, LogFormat: JSONFormat}},
+				Redaction: &RedactionConfig{
+					FieldRules: []FieldRule{{Field: "password"}},
+					ValueRules: []ValueRule{{Pattern: regexp.MustCompile(`secret`)}},
+				},
+			})
+			named := logger.Named("payment")
+			output := tt.mutate(t, named, buf)
+			if output == nil {
+				output = buf.buf.Bytes
+			}
+
+			named.WithField("password", "secret").Info("token secret")
+			logger.Named("payment").Named("db").Info("child entry")
+
+			data := output()
+			if bytes.Contains(data, []byte("secret")) {
+				t.Errorf("entry has secret: %s", data)
+			}
+			var entries []map[string]interface{}
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			for decoder.More() {
+				var entry map[string]interface{}
+				if err := decoder.Decode(&entry); err != nil {
+					t.Fatalf("decode %q: %v", data, err)
+				}
+				entries = append(entries, entry)
+			}
+			if len(entries) != 2 {
+				t.Fatalf("%d entries are written, want 2: %s", len(entries), data)
+			}
+			assertEntry(t, entries[0], map[string]interface{}{
+				"password":         RedactedMask,
+				"msg":              "token " + RedactedMask,
+				DefaultModuleField: "payment",
+			})
+			assertEntry(t, entries[1], map[string]interface{}{DefaultModuleField: "payment.db"})
+			if tt.name == "SetDefaultFields" && entries[0]["app"] != "test" {
+				t.Errorf("default field is not logged: %v", entries[0])
+			}
+			if timestamp, _ := entries[0]["time"].(string); tt.name == "SetTimestampFormat" && len(timestamp) != 4 {
+				t.Errorf("time = %v, want format 2006", entries[0]["time"])
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package logadapter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RedactedMask replacement of RedactMask strategy
+	RedactedMask          = "******"
+	defaultTruncateLength = 4
+	maxRedactDepth        = 16 // nested values deeper than this are not redacted
+)
+
+// Common patterns of sensitive value for ValueRule
+var (
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	JWTPattern        = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	EmailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// RedactionConfig config for redact sensitive fields before formatting, apply to all log fields and message
+type RedactionConfig struct {
+	FieldRules []FieldRule
+	ValueRules []ValueRule
+}
+
+// FieldRule redact whole value of log field by field name
+type FieldRule struct {
+	Field          string // field name, case insensitive
+	Strategy       RedactStrategy
+	TruncateLength int // number of characters kept by RedactTruncate, default 4
+}
+
+// ValueRule redact part of string value matched pattern, e.g. CreditCardPattern
+type ValueRule struct {
+	Pattern        *regexp.Regexp
+	Strategy       RedactStrategy
+	TruncateLength int // number of characters kept by RedactTruncate, default 4
+}
+
+// redactor apply redaction rules to log fields
+type redactor struct {
+	fieldRules map[string]FieldRule
+	valueRules []ValueRule
+}
+
+func newRedactor(config *RedactionConfig) *redactor {
+	if config == nil || (len(config.FieldRules) == 0 && len(config.ValueRules) == 0) {
+		return nil
+	}
+
+	r := &redactor{fieldRules: make(map[string]FieldRule, len(config.FieldRules))}
+	for _, rule := range config.FieldRules {
+		r.fieldRules[strings.ToLower(rule.Field)] = rule
+	}
+	for _, rule := range config.ValueRules {
+		if rule.Pattern != nil {
+			r.valueRules = append(r.valueRules, rule)
+		}
+	}
+
+	return r
+}
+
+// redactFields returns redacted copy of fields, nested fields are redacted too
+func (r *redactor) redactFields(fields map[string]interface{}) map[string]interface{} {
+	redacted, _ := r.redactMap(fields, 0)
+
+	return redacted
+}
+
+// redactMap returns redacted copy of fields and true if any field is changed
+func (r *redactor) redactMap(fields map[string]interface{}, depth int) (map[string]interface{}, bool) {
+	redacted := make(map[string]interface{}, len(fields))
+	var isChanged bool
+	for key, value := range fields {
+		if rule, ok := r.fieldRules[strings.ToLower(key)]; ok {
+			if rule.Strategy != RedactDrop {
+				redacted[key] = redact(fmt.Sprint(value), rule.Strategy, rule.TruncateLength)
+			}
+			isChanged = true
+			continue
+		}
+		value, ok := r.redactValue(value, depth+1)
+		redacted[key] = value
+		isChanged = isChanged || ok
+	}
+
+	return redacted, isChanged
+}
+
+// redactValue returns redacted value and true if value is changed, otherwise value is returned as is.
+// Slices, arrays, maps with string keys and pointers are walked by reflection, structs are walked after JSON encoding
+func (r *redactor) redactValue(value interface{}, depth int) (interface{}, bool) {
+	if value == nil || depth > maxRedactDepth {
+		return value, false
+	}
+
+	switch v := value.(type) {
+	case string:
+		s := r.redactString(v)
+		return s, s != v
+	case []byte:
+		if s := r.redactString(string(v)); s != string(v) {
+			return s, true
+		}
+		return value, false
+	case map[string]interface{}:
+		return r.redactMap(v, depth)
+	case error:
+		if s := v.Error(); r.redactString(s) != s {
+			return r.redactString(s), true
+		}
+	case fmt.Stringer:
+		if s := v.String(); r.redactString(s) != s {
+			return r.redactString(s), true
+		}
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !rv.IsNil() {
+			if redacted, ok := r.redactValue(rv.Elem().Interface(), depth+1); ok {
+				return redacted, true
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, rv.Len())
+		var isChanged bool
+		for i := range items {
+			item, ok := r.redactValue(rv.Index(i).Interface(), depth+1)
+			items[i] = item
+			isChanged = isChanged || ok
+		}
+		if isChanged {
+			return items, true
+		}
+
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		fields := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			fields[iter.Key().String()] = iter.Value().Interface()
+		}
+		if redacted, ok := r.redactMap(fields, depth); ok {
+			return redacted, true
+		}
+
+	case reflect.Struct:
+		data, err := json.Marshal(value)
+		if err != nil {
+			break
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var decoded interface{}
+		if err := decoder.Decode(&decoded); err != nil {
+			break
+		}
+		if redacted, ok := r.redactValue(decoded, depth+1); ok {
+			return redacted, true
+		}
+	}
+
+	return value, false
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, rule := range r.valueRules {
+		rule := rule
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			return redact(match, rule.Strategy, rule.TruncateLength)
+		})
+	}
+
+	return s
+}
+
+// redact replace value by strategy
+func redact(value string, strategy RedactStrategy, truncateLength int) string {
+	switch strategy {
+	case RedactHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:])
+
+	case RedactDrop:
+		return ""
+
+	case RedactTruncate:
+		if truncateLength <= 0 {
+			truncateLength = defaultTruncateLength
+		}
+		runes := []rune(value)
+		if len(runes) <= truncateLength {
+			return value
+		}
+		return string(runes[:truncateLength]) + "..."
+
+	default:
+		return RedactedMask
+	}
+}
+
+// redactFormat redact log fields and message before formatting
+type redactFormat struct {
+	redactor  *redactor
+	formatter log.Formatter
+}
+
+func (rf redactFormat) Format(entry *log.Entry) ([]byte, error) {
+	entry.Data = rf.redactor.redactFields(entry.Data)
+	entry.Message = rf.redactor.redactString(entry.Message)
+
+	return rf.formatter.Format(entry)
+}
+
+// SetRedaction set redaction rules, set null to disable redaction
+func SetRedaction(config *RedactionConfig) { GetLogger().SetRedaction(config) }
+
+// SetRedaction set redaction rules, set null to disable redaction
+func (l *Logger) SetRedaction(config *RedactionConfig) {
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.redactor = newRedactor(config)
+	l.setFormatter(l.formatter)
+}
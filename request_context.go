@@ -0,0 +1,63 @@
+package logadapter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// HeaderMapping map request header to log key
+type HeaderMapping struct {
+	Header          HeaderKey
+	LogKey          LogKey
+	Generator       func() string // generate value if header is absent, set null if not generate
+	IsEchoResponse  bool          // set value to response header
+	IsIgnoreInbound bool          // not trust inbound header and trace context, always generate value
+}
+
+// DefaultHeaderMappings default header mappings of correlation id, request id and user info
+var DefaultHeaderMappings = []HeaderMapping{
+	{Header: CorrelationIDHeaderKey, LogKey: CorrelationIDLogKey, Generator: generateCorrelationID, IsEchoResponse: true},
+	{Header: RequestIDHeaderKey, LogKey: RequestIDLogKey, Generator: uuid.NewString, IsEchoResponse: true},
+	{Header: UserInfoHeaderKey, LogKey: UserInfoLogKey},
+}
+
+// DefaultPropagatedHeaderMappings default header mappings propagated to outbound requests, correlation id and request id
+var DefaultPropagatedHeaderMappings = []HeaderMapping{
+	{Header: CorrelationIDHeaderKey, LogKey: CorrelationIDLogKey},
+	{Header: RequestIDHeaderKey, LogKey: RequestIDLogKey},
+}
+
+// setRequestContext sets log fields of request headers to context, echoes them to response header
+// and returns new context. Trace id is used as correlation id if correlation id header is absent.
+// Fields are logged with request context only, log keys of logger are not changed
+func setRequestContext(ctx context.Context, header, responseHeader http.Header, headers []HeaderMapping, isEnableB3 bool) context.Context {
+	ctx, spanContext := extractTraceContext(ctx, header, isEnableB3)
+	fields := make(map[string]interface{}, len(headers))
+	for _, mapping := range headers {
+		var value string
+		if !mapping.IsIgnoreInbound {
+			value = header.Get(string(mapping.Header))
+		}
+		if value == "" && mapping.LogKey == CorrelationIDLogKey && spanContext.IsValid() && !mapping.IsIgnoreInbound {
+			value = spanContext.TraceID().String()
+		}
+		if value == "" && mapping.Generator != nil {
+			value = mapping.Generator()
+		}
+		if value == "" {
+			continue
+		}
+
+		// value of log key is read by outbound propagation
+		ctx = setContextKeyValue(ctx, string(mapping.LogKey), value)
+		fields[string(mapping.LogKey)] = value
+		if mapping.IsEchoResponse {
+			responseHeader.Set(string(mapping.Header), value)
+		}
+	}
+	injectTraceContext(responseHeader, spanContext, isEnableB3)
+
+	return WithFields(ctx, fields)
+}
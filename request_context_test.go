@@ -0,0 +1,114 @@
+package logadapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHeaderMappingsAreContextScoped(t *testing.T) {
+	buf := setTestLogger(t)
+	keys := GetLogKeys()
+	handler := NewHTTPLoggerMiddlewareWithConfig(HTTPLoggerConfig{
+		Headers: []HeaderMapping{
+			{Header: CorrelationIDHeaderKey, LogKey: CorrelationIDLogKey},
+			{Header: "X-Tenant-ID", LogKey: "tenant_id"},
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		InfoWithContext(r.Context(), "handler")
+	}))
+
+	// logger is replaced after middleware is created
+	SetLogger(newTestLogger(buf))
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("X-Tenant-ID", "tenant-1")
+	req.Header.Set(string(CorrelationIDHeaderKey), "correlation-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := buf.entries(t)
+	if len(entries) != 2 {
+		t.Fatalf("%d entries are written, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		assertEntry(t, entry, map[string]interface{}{"tenant_id": "tenant-1", string(CorrelationIDLogKey): "correlation-1"})
+	}
+	if got := GetLogKeys(); len(got) != len(keys) {
+		t.Errorf("log keys = %v, want %v", got, keys)
+	}
+}
+
+func TestHeaderMappingIgnoreInbound(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	generated := func() string { return "generated" }
+	tests := []struct {
+		name            string
+		isIgnoreInbound bool
+		want            string
+	}{
+		{name: "trusted", want: traceID},
+		{name: "ignore inbound", isIgnoreInbound: true, want: "generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(string(TraceParentHeaderKey), "00-"+traceID+"-00f067aa0ba902b7-01")
+			header.Set(string(B3TraceIDHeaderKey), traceID)
+			header.Set(string(B3SpanIDHeaderKey), "00f067aa0ba902b7")
+			mappings := []HeaderMapping{{
+				Header:          CorrelationIDHeaderKey,
+				LogKey:          CorrelationIDLogKey,
+				Generator:       generated,
+				IsIgnoreInbound: tt.isIgnoreInbound,
+			}}
+
+			for _, isEnableB3 := range []bool{false, true} {
+				if isEnableB3 {
+					header.Del(string(TraceParentHeaderKey))
+				}
+				ctx := setRequestContext(context.Background(), header, http.Header{}, mappings, isEnableB3)
+				if got := getContextFields(ctx)[string(CorrelationIDLogKey)]; got != tt.want {
+					t.Errorf("correlation id with B3 %v = %v, want %v", isEnableB3, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	var nilCtx context.Context
+	parent := WithFields(nilCtx, map[string]interface{}{"tenant_id": "t1"})
+	if parent == nil {
+		t.Fatal("WithFields(nil) returns nil context")
+	}
+	keys := GetLogKeys()
+
+	child := WithFields(parent, map[string]interface{}{"order_id": "o1", "tenant_id": "t2"})
+	sibling := WithFields(parent, map[string]interface{}{"payment_id": "p1"})
+	grandchild, cancel := context.WithCancel(child)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]interface{}
+	}{
+		{name: "parent", ctx: parent, want: map[string]interface{}{"tenant_id": "t1"}},
+		{name: "child", ctx: child, want: map[string]interface{}{"tenant_id": "t2", "order_id": "o1"}},
+		{name: "sibling", ctx: sibling, want: map[string]interface{}{"tenant_id": "t1", "payment_id": "p1"}},
+		{name: "grandchild", ctx: grandchild, want: map[string]interface{}{"tenant_id": "t2", "order_id": "o1"}},
+		{name: "background", ctx: context.Background(), want: map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := GetLogFieldFromContext(tt.ctx)
+			if !reflect.DeepEqual(fields, tt.want) {
+				t.Errorf("GetLogFieldFromContext() = %v, want %v", fields, tt.want)
+			}
+		})
+	}
+	if !reflect.DeepEqual(GetLogKeys(), keys) {
+		t.Errorf("GetLogKeys() = %v, want %v", GetLogKeys(), keys)
+	}
+}
@@ -0,0 +1,209 @@
+package logadapter
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// time format and suffix of backup file names of lumberjack
+const (
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+)
+
+// timeRotateWriter write log to file, rotate log file at wall-clock interval.
+// If FileConfig.FilenamePattern is set, file name is re-evaluated at each rotation,
+// otherwise current file is renamed to backup file by lumberjack
+type timeRotateWriter struct {
+	config       FileConfig
+	location     *time.Location
+	mu           sync.Mutex
+	file         *lumberjack.Logger
+	nextRotation time.Time
+}
+
+func newTimeRotateWriter(fileConfig *FileConfig) *timeRotateWriter {
+	location := time.UTC
+	if fileConfig.IsUseLocalTime {
+		location = time.Local
+	}
+
+	return &timeRotateWriter{
+		config:   *fileConfig,
+		location: location,
+	}
+}
+
+// Write write log to current log file, rotate it if rotation time is reached
+func (w *timeRotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now().In(w.location)
+	if w.file == nil || !now.Before(w.nextRotation) {
+		if err := w.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.file.Write(p)
+}
+
+// Close close current log file
+func (w *timeRotateWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+
+	return err
+}
+
+func (w *timeRotateWriter) rotate(now time.Time) error {
+	w.nextRotation = w.nextPeriodStart(now)
+
+	if len(w.config.FilenamePattern) == 0 {
+		if w.file == nil {
+			w.file = w.newFile(w.config.Filename)
+			return nil
+		}
+		return w.file.Rotate()
+	}
+
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+	dir, layout := filepath.Split(w.config.FilenamePattern)
+	filename := filepath.Join(dir, now.Format(layout))
+	w.file = w.newFile(filename)
+	w.removeOldFiles(dir, layout, filename, now)
+
+	return nil
+}
+
+func (w *timeRotateWriter) newFile(filename string) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    w.config.MaxSize,
+		MaxBackups: w.config.MaxBackups,
+		MaxAge:     w.config.MaxAge,
+		Compress:   w.config.IsCompress,
+		LocalTime:  w.config.IsUseLocalTime,
+	}
+}
+
+// nextPeriodStart returns start time of next rotation period.
+// Days are not always 24 hours in local time, and an hour is repeated when daylight saving time ends
+func (w *timeRotateWriter) nextPeriodStart(now time.Time) time.Time {
+	if w.config.RotationInterval == RotateHourly {
+		elapsed := time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second +
+			time.Duration(now.Nanosecond())
+		return now.Add(time.Hour - elapsed)
+	}
+
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, w.location)
+}
+
+// removeOldFiles remove log files matched file name pattern and their backups rotated by size,
+// keep MaxBackups files not older than MaxAge days. Lumberjack only removes backups of current file
+func (w *timeRotateWriter) removeOldFiles(dir, layout, current string, now time.Time) {
+	if w.config.MaxBackups <= 0 && w.config.MaxAge <= 0 {
+		return
+	}
+	if len(dir) == 0 {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	var files []logFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if path == current {
+			continue
+		}
+		if file, ok := w.parseLogFile(layout, entry.Name()); ok {
+			file.path = path
+			files = append(files, file)
+		}
+	}
+	// newest period first, file of period before its backups
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].period.Equal(files[j].period) {
+			return files[i].period.After(files[j].period)
+		}
+		if files[i].rotated.IsZero() != files[j].rotated.IsZero() {
+			return files[i].rotated.IsZero()
+		}
+		return files[i].rotated.After(files[j].rotated)
+	})
+
+	cutoff := now.Add(-time.Duration(w.config.MaxAge) * 24 * time.Hour)
+	for i, file := range files {
+		if (w.config.MaxBackups > 0 && i >= w.config.MaxBackups) ||
+			(w.config.MaxAge > 0 && file.time().Before(cutoff)) {
+			_ = os.Remove(file.path)
+		}
+	}
+}
+
+// logFile log file of rotation period, or its backup rotated by size
+type logFile struct {
+	path    string
+	period  time.Time
+	rotated time.Time // rotation time of backup, zero if it's log file of period
+}
+
+func (f logFile) time() time.Time {
+	if f.rotated.IsZero() {
+		return f.period
+	}
+
+	return f.rotated
+}
+
+// parseLogFile parse file name matched layout, or name of its backup, e.g. log_2006-01-02-2006-01-02T15-04-05.000.log.gz
+func (w *timeRotateWriter) parseLogFile(layout, name string) (logFile, bool) {
+	if period, err := time.ParseInLocation(layout, name, w.location); err == nil {
+		return logFile{period: period}, true
+	}
+
+	ext := filepath.Ext(layout)
+	name = strings.TrimSuffix(name, compressSuffix)
+	base, ok := strings.CutSuffix(name, ext)
+	if !ok || len(base) <= len(backupTimeFormat) {
+		return logFile{}, false
+	}
+	prefix, timestamp := base[:len(base)-len(backupTimeFormat)], base[len(base)-len(backupTimeFormat):]
+	prefix, ok = strings.CutSuffix(prefix, "-")
+	if !ok {
+		return logFile{}, false
+	}
+	rotated, err := time.ParseInLocation(backupTimeFormat, timestamp, w.location)
+	if err != nil {
+		return logFile{}, false
+	}
+	period, err := time.ParseInLocation(layout, prefix+ext, w.location)
+	if err != nil {
+		return logFile{}, false
+	}
+
+	return logFile{period: period, rotated: rotated}, true
+}
@@ -0,0 +1,166 @@
+package logadapter
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimeRotateWriterNextPeriodStart(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("load location: %v", err)
+	}
+	utc := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		interval RotationInterval
+		now      time.Time
+		want     time.Time
+	}{
+		{name: "daily", interval: RotateDaily, now: utc(time.October, 10, 14, 30), want: utc(time.October, 11, 4, 0)},
+		{name: "daily fall back", interval: RotateDaily, now: utc(time.November, 1, 15, 0), want: utc(time.November, 2, 5, 0)},
+		{name: "daily spring forward", interval: RotateDaily, now: utc(time.March, 8, 14, 0), want: utc(time.March, 9, 4, 0)},
+		{name: "hourly", interval: RotateHourly, now: utc(time.October, 10, 14, 30), want: utc(time.October, 10, 15, 0)},
+		{name: "hourly first 1am of fall back", interval: RotateHourly, now: utc(time.November, 1, 5, 30), want: utc(time.November, 1, 6, 0)},
+		{name: "hourly second 1am of fall back", interval: RotateHourly, now: utc(time.November, 1, 6, 30), want: utc(time.November, 1, 7, 0)},
+		{name: "hourly spring forward", interval: RotateHourly, now: utc(time.March, 8, 6, 30), want: utc(time.March, 8, 7, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTimeRotateWriter(&FileConfig{RotationInterval: tt.interval, IsUseLocalTime: true})
+			w.location = location
+
+			got := w.nextPeriodStart(tt.now.In(location))
+			if !got.Equal(tt.want) {
+				t.Errorf("nextPeriodStart(%v) = %v, want %v", tt.now.In(location), got, tt.want.In(location))
+			}
+		})
+	}
+}
+
+func TestTimeRotateWriterRotate(t *testing.T) {
+	dir := t.TempDir()
+	w := newTimeRotateWriter(&FileConfig{
+		RotationInterval: RotateDaily,
+		FilenamePattern:  filepath.Join(dir, "log_2006-01-02.log"),
+		MaxBackups:       1,
+	})
+	defer w.Close()
+
+	for day, text := range []string{10: "a", 11: "b", 12: "c"} {
+		if len(text) == 0 {
+			continue
+		}
+		now := time.Date(2026, time.October, day, 10, 0, 0, 0, time.UTC)
+		if err := w.rotate(now); err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Date(2026, time.October, day+1, 0, 0, 0, 0, time.UTC); !w.nextRotation.Equal(want) {
+			t.Errorf("next rotation %v, want %v", w.nextRotation, want)
+		}
+		if _, err := w.file.Write([]byte(text)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	// file of 2026-10-10 is removed by MaxBackups
+	want := map[string]string{"log_2026-10-11.log": "b", "log_2026-10-12.log": "c"}
+	names := readDirNames(t, dir)
+	if len(names) != len(want) {
+		t.Fatalf("files %v, want %v", names, want)
+	}
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want[name] {
+			t.Errorf("file %s has %q, want %q", name, data, want[name])
+		}
+	}
+}
+
+func TestTimeRotateWriterRemoveOldFiles(t *testing.T) {
+	files := []string{
+		"log_2026-10-10.log",
+		"log_2026-10-10-2026-10-10T10-00-00.000.log",
+		"log_2026-10-11.log",
+		"log_2026-10-11-2026-10-11T10-00-00.000.log.gz",
+		"log_2026-10-12.log",
+		"log_2026-10-13.log",
+		"other.log",
+	}
+	tests := []struct {
+		name       string
+		maxBackups int
+		maxAge     int
+		want       []string
+	}{
+		{
+			name:       "max backups",
+			maxBackups: 2,
+			want:       []string{"log_2026-10-11.log", "log_2026-10-12.log", "log_2026-10-13.log", "other.log"},
+		},
+		{
+			name:   "max age",
+			maxAge: 2,
+			want: []string{
+				"log_2026-10-11-2026-10-11T10-00-00.000.log.gz",
+				"log_2026-10-11.log",
+				"log_2026-10-12.log",
+				"log_2026-10-13.log",
+				"other.log",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			w := newTimeRotateWriter(&FileConfig{
+				RotationInterval: RotateDaily,
+				MaxBackups:       tt.maxBackups,
+				MaxAge:           tt.maxAge,
+			})
+
+			now := time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC)
+			w.removeOldFiles(dir, "log_2006-01-02.log", filepath.Join(dir, "log_2026-10-13.log"), now)
+
+			got := readDirNames(t, dir)
+			if len(got) != len(tt.want) {
+				t.Fatalf("files %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("files %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func readDirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
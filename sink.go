@@ -0,0 +1,176 @@
+package logadapter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Sink config for one log output, log entry is written to sink if it passes both Config.LogLevel and Sink.LogLevel.
+// LogFormat and TimestampFormat of sink win over Config.LogFormat and Config.TimestampFormat, until SetFormatter or
+// SetTimestampFormat is called, then all sinks use format of logger
+type Sink struct {
+	Writer          io.Writer // if null, use console writer of logger. Use NewFileWriter to write to file
+	LogLevel        Level
+	LogFormat       LogFormat
+	TimestampFormat string // if empty, use default timestamp format
+}
+
+// sinkOutput log output with its own level and formatter
+type sinkOutput struct {
+	level     Level
+	formatter log.Formatter
+	output    levelWriter
+}
+
+// sinkFormat format log entry by formatter of each sink and write it to sink output, logrus writes nothing after that
+type sinkFormat []sinkOutput
+
+func (sf sinkFormat) Format(entry *log.Entry) ([]byte, error) {
+	buffer := entry.Buffer
+	defer func() { entry.Buffer = buffer }()
+
+	for _, sink := range sf {
+		if Level(entry.Level) > sink.level {
+			continue
+		}
+		// formatter appends to entry buffer, each sink needs its own buffer
+		entry.Buffer = nil
+		b, err := sink.formatter.Format(entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to obtain reader, %v\n", err)
+			continue
+		}
+		if _, err := sink.output.WriteLevel(Level(entry.Level), b); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write to log, %v\n", err)
+		}
+	}
+
+	return nil, nil
+}
+
+// lockedWriter write to writer with lock, lock is shared by logger and its named loggers
+type lockedWriter struct {
+	mu *sync.Mutex
+	io.Writer
+}
+
+func (w lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.Writer.Write(p)
+}
+
+// syncWriter write log entry directly to writer
+type syncWriter struct {
+	io.Writer
+}
+
+func (w syncWriter) WriteLevel(_ Level, p []byte) (int, error) {
+	return w.Write(p)
+}
+
+// SetSinks set many log outputs, each with its own level and format
+func SetSinks(sinks []Sink) { GetLogger().SetSinks(sinks) }
+
+// SetSinks set many log outputs, each with its own level and format.
+// Replace current output, use SetLogConsole or SetLogFile to go back to single output
+func (l *Logger) SetSinks(sinks []Sink) {
+	l = l.root()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(sinks) == 0 {
+		l.setOutput(l.console())
+		return
+	}
+
+	previous := l.asyncWriters()
+	previousFile := l.fileOutput
+	outputs := make([]sinkOutput, 0, len(sinks))
+	for _, sink := range sinks {
+		outputs = append(outputs, l.newSinkOutput(sink))
+	}
+	l.sinks = outputs
+	l.asyncOutput = nil
+	l.fileOutput = nil
+	l.Logger.SetOutput(io.Discard)
+	l.setFormatter(l.formatter)
+	closeAsyncWriters(previous)
+	closeFileOutput(previousFile)
+}
+
+// setSinkFormat replace formatter of all sinks, l.mu must be held.
+// Sinks are copied, formatter chain of logger is rebuilt by setFormatter
+func (l *Logger) setSinkFormat(logFormat LogFormat, timestampFormat string) {
+	if len(l.sinks) == 0 {
+		return
+	}
+	if len(timestampFormat) == 0 {
+		timestampFormat = DefaultTimestampFormat
+	}
+
+	sinks := make([]sinkOutput, 0, len(l.sinks))
+	for _, sink := range l.sinks {
+		sink.formatter = newFormatter(logFormat, timestampFormat)
+		sinks = append(sinks, sink)
+	}
+	l.sinks = sinks
+}
+
+// hasSinks check root logger writes to sinks
+func (l *Logger) hasSinks() bool {
+	root := l.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	return len(root.sinks) > 0
+}
+
+func (l *Logger) newSinkOutput(sink Sink) sinkOutput {
+	writer := sink.Writer
+	if writer == nil {
+		writer = l.console()
+	}
+	timestampFormat := sink.TimestampFormat
+	if len(timestampFormat) == 0 {
+		timestampFormat = DefaultTimestampFormat
+	}
+
+	writer = lockedWriter{mu: &l.root().writeMu, Writer: writer}
+	var output levelWriter = syncWriter{Writer: writer}
+	if l.asyncConfig != nil {
+		output = newAsyncWriter(writer, l.asyncConfig, l.reportDroppedEntries)
+	}
+
+	return sinkOutput{
+		level:     sink.LogLevel,
+		formatter: newFormatter(sink.LogFormat, timestampFormat),
+		output:    output,
+	}
+}
+
+// asyncWriters returns all async writers of logger, l.mu must be held
+func (l *Logger) asyncWriters() []*asyncWriter {
+	var writers []*asyncWriter
+	if l.asyncOutput != nil {
+		writers = append(writers, l.asyncOutput)
+	}
+	for _, sink := range l.sinks {
+		if writer, ok := sink.output.(*asyncWriter); ok {
+			writers = append(writers, writer)
+		}
+	}
+
+	return writers
+}
+
+func closeAsyncWriters(writers []*asyncWriter) {
+	for _, writer := range writers {
+		_ = writer.Close()
+	}
+}
@@ -0,0 +1,45 @@
+package logadapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSetFormatterWithSinks(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	logger := NewWithConfig(&Config{
+		LogLevel:  DebugLevel,
+		LogFormat: TextFormat,
+		Sinks: []Sink{
+			{Writer: &jsonBuf, LogLevel: DebugLevel, LogFormat: JSONFormat},
+			{Writer: &textBuf, LogLevel: InfoLevel, LogFormat: TextFormat},
+		},
+	})
+
+	logger.Debug("sink format")
+	if !json.Valid(jsonBuf.Bytes()) {
+		t.Errorf("JSON sink writes %q", jsonBuf.String())
+	}
+	if textBuf.Len() != 0 {
+		t.Errorf("info sink writes debug entry %q", textBuf.String())
+	}
+
+	jsonBuf.Reset()
+	logger.SetFormatter(TextFormat)
+	logger.SetTimestampFormat("2006")
+	logger.Info("logger format")
+	if got := logger.GetFormatter(); got != TextFormat {
+		t.Errorf("GetFormatter() = %v, want %v", got, TextFormat)
+	}
+	for _, output := range []string{jsonBuf.String(), textBuf.String()} {
+		if json.Valid([]byte(output)) || !strings.Contains(output, `msg="logger format"`) {
+			t.Errorf("sink writes %q, want text format", output)
+		}
+		if !regexp.MustCompile(`time=\d{4} `).MatchString(output) {
+			t.Errorf("sink writes %q, want timestamp format 2006", output)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package logadapter
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// slogSourcePath is skipped in stack trace field, it's the log/slog package itself
+const slogSourcePath = "log/slog/"
+
+// SlogHandler implements slog.Handler, write log record through Logger
+type SlogHandler struct {
+	logger *Logger
+	fields map[string]interface{}
+	groups []string
+}
+
+// NewSlogHandler returns slog handler backed by singleton logger
+func NewSlogHandler() *SlogHandler {
+	return NewSlogHandlerWithLogger(GetLogger())
+}
+
+// NewSlogHandlerWithLogger returns slog handler backed by logger
+func NewSlogHandlerWithLogger(logger *Logger) *SlogHandler {
+	return &SlogHandler{
+		logger: logger,
+		fields: make(map[string]interface{}),
+	}
+}
+
+// NewSlogLogger returns slog logger backed by singleton logger
+func NewSlogLogger() *slog.Logger {
+	return slog.New(NewSlogHandler())
+}
+
+// Enabled reports whether the handler handles records at the given level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.IsLevelEnabled(logrus.Level(fromSlogLevel(level)))
+}
+
+// Handle log a slog record with log fields from context
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fields := cloneLogFields(h.fields)
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	setSlogAttrs(fields, h.groups, attrs)
+	fields = mergeLogFields(h.logger.GetLogFieldFromContext(ctx), fields)
+
+	level := fromSlogLevel(record.Level)
+	if level <= WarnLevel {
+		fields[DefaultSourceField] = h.logger.getCaller(slogSourcePath)
+	}
+
+	// logrus always sets time of entry, record without time is formatted without timestamp
+	if record.Time.IsZero() {
+		ctx = withoutTimestamp(ctx)
+	}
+	entry := h.logger.WithContext(ctx).WithFields(fields)
+	if !record.Time.IsZero() {
+		entry = entry.WithTime(record.Time)
+	}
+	entry.Log(logrus.Level(level), record.Message)
+
+	return nil
+}
+
+// WithAttrs returns a new handler, attrs are logged as fields in current group
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	handler := h.clone()
+	setSlogAttrs(handler.fields, handler.groups, attrs)
+
+	return handler
+}
+
+// WithGroup returns a new handler, next attrs are logged as nested fields of group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := h.clone()
+	handler.groups = append(handler.groups[:len(handler.groups):len(handler.groups)], name)
+
+	return handler
+}
+
+func (h *SlogHandler) clone() *SlogHandler {
+	return &SlogHandler{
+		logger: h.logger,
+		fields: cloneLogFields(h.fields),
+		groups: h.groups,
+	}
+}
+
+// fromSlogLevel convert slog level to log level
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level >= slog.LevelError:
+		return ErrorLevel
+	case level >= slog.LevelWarn:
+		return WarnLevel
+	case level >= slog.LevelInfo:
+		return InfoLevel
+	case level >= slog.LevelDebug:
+		return DebugLevel
+	}
+
+	return TraceLevel
+}
+
+// setSlogAttrs set attrs to fields, nested by groups
+func setSlogAttrs(fields map[string]interface{}, groups []string, attrs []slog.Attr) {
+	if len(attrs) == 0 {
+		return
+	}
+	for _, group := range groups {
+		child, ok := fields[group].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			fields[group] = child
+		}
+		fields = child
+	}
+	for _, attr := range attrs {
+		setSlogAttr(fields, attr)
+	}
+}
+
+func setSlogAttr(fields map[string]interface{}, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		fields[attr.Key] = slogValue(attr.Value)
+		return
+	}
+
+	attrs := attr.Value.Group()
+	if len(attrs) == 0 {
+		return
+	}
+	if attr.Key == "" {
+		// inline group
+		for _, a := range attrs {
+			setSlogAttr(fields, a)
+		}
+		return
+	}
+	setSlogAttrs(fields, []string{attr.Key}, attrs)
+}
+
+func slogValue(value slog.Value) interface{} {
+	v := value.Any()
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+
+	return v
+}
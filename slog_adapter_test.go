@@ -0,0 +1,46 @@
+package logadapter
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestSlogHandler(t *testing.T) {
+	buf := &syncBuffer{}
+	handler := NewSlogHandlerWithLogger(newTestLogger(buf))
+
+	err := slogtest.TestHandler(handler, func() []map[string]any {
+		return buf.entries(t)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSlogHandlerZeroTime(t *testing.T) {
+	buf := &syncBuffer{}
+	logger := newTestLogger(buf)
+	logger.SetSinks([]Sink{
+		{Writer: buf, LogLevel: TraceLevel, LogFormat: JSONFormat},
+		{Writer: buf, LogLevel: TraceLevel, LogFormat: JSONFormat},
+	})
+	handler := NewSlogHandlerWithLogger(logger)
+
+	if err := handler.Handle(context.Background(), slog.Record{Level: slog.LevelInfo, Message: "without time"}); err != nil {
+		t.Fatal(err)
+	}
+	slog.New(handler).Info("with time")
+
+	entries := buf.entries(t)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(entries))
+	}
+	for i, entry := range entries {
+		_, ok := entry["time"]
+		if want := i >= 2; ok != want {
+			t.Errorf("entry %d has time %v, want %v", i, ok, want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package logadapter
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// stdLogSourcePath is skipped in stack trace field, it's the log package itself
+const stdLogSourcePath = "src/log/log.go"
+
+// levelPrefixes level prefixes are detected at start of line, case insensitive
+var levelPrefixes = []struct {
+	prefix string
+	level  Level
+}{
+	{"[TRACE]", TraceLevel},
+	{"[DEBUG]", DebugLevel},
+	{"[INFO]", InfoLevel},
+	{"[WARN]", WarnLevel},
+	{"[WARNING]", WarnLevel},
+	{"[ERROR]", ErrorLevel},
+	{"[ERR]", ErrorLevel},
+	{"[FATAL]", FatalLevel},
+	{"TRACE:", TraceLevel},
+	{"DEBUG:", DebugLevel},
+	{"INFO:", InfoLevel},
+	{"WARN:", WarnLevel},
+	{"WARNING:", WarnLevel},
+	{"ERROR:", ErrorLevel},
+	{"FATAL:", FatalLevel},
+}
+
+// LineWriter implements io.Writer, each written line is logged as an entry
+type LineWriter struct {
+	Level         Level  // log level of line without level prefix
+	LogType       string // type field of entry, default LogTypeStdLog
+	IsDetectLevel bool   // detect level prefixes like [ERROR] or ERROR:, prefix is removed from message
+
+	logger *Logger
+	mu     sync.Mutex
+	buf    []byte
+}
+
+// Writer returns io.Writer log each line at level, level prefixes are detected
+func Writer(level Level) *LineWriter { return GetLogger().Writer(level) }
+
+// Writer returns io.Writer log each line at level, level prefixes are detected
+func (l *Logger) Writer(level Level) *LineWriter {
+	return &LineWriter{
+		Level:         level,
+		LogType:       LogTypeStdLog,
+		IsDetectLevel: true,
+		logger:        l,
+	}
+}
+
+// StdLogger returns standard library logger log each line at level, e.g. http.Server.ErrorLog
+func StdLogger(level Level) *log.Logger { return GetLogger().StdLogger(level) }
+
+// StdLogger returns standard library logger log each line at level, e.g. http.Server.ErrorLog
+func (l *Logger) StdLogger(level Level) *log.Logger {
+	return log.New(l.Writer(level), "", 0)
+}
+
+// Write log complete lines, incomplete line is buffered until next write or Close
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Close log buffered incomplete line
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.logLine(string(w.buf))
+		w.buf = nil
+	}
+
+	return nil
+}
+
+func (w *LineWriter) logLine(line string) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	level := w.Level
+	if w.IsDetectLevel {
+		if detected, message, ok := detectLevel(line); ok {
+			level, line = detected, message
+		}
+	}
+
+	fields := map[string]interface{}{"type": w.LogType}
+	if level <= WarnLevel {
+		fields[DefaultSourceField] = w.logger.getCaller(stdLogSourcePath)
+	}
+	w.logger.WithFields(fields).Log(logrus.Level(level), line)
+}
+
+// detectLevel get level from level prefix of line, returns message without prefix
+func detectLevel(line string) (Level, string, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	for _, levelPrefix := range levelPrefixes {
+		if len(trimmed) >= len(levelPrefix.prefix) && strings.EqualFold(trimmed[:len(levelPrefix.prefix)], levelPrefix.prefix) {
+			return levelPrefix.level, strings.TrimLeft(trimmed[len(levelPrefix.prefix):], " \t"), true
+		}
+	}
+
+	return 0, line, false
+}
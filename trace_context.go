@@ -0,0 +1,137 @@
+package logadapter
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// extractTraceContext gets span context from W3C traceparent and tracestate headers,
+// or B3 headers if enableB3 is true. Span context of active span in context is preferred
+func extractTraceContext(ctx context.Context, header http.Header, enableB3 bool) (context.Context, trace.SpanContext) {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		return ctx, spanContext
+	}
+
+	spanContext, ok := parseTraceParent(header.Get(string(TraceParentHeaderKey)))
+	if ok {
+		if traceState, err := trace.ParseTraceState(header.Get(string(TraceStateHeaderKey))); err == nil {
+			spanContext = spanContext.WithTraceState(traceState)
+		}
+	} else if enableB3 {
+		spanContext, ok = parseB3(header)
+	}
+	if !ok {
+		return ctx, trace.SpanContext{}
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext), spanContext
+}
+
+// injectTraceContext sets W3C traceparent and tracestate headers, and B3 header if enableB3 is true
+func injectTraceContext(header http.Header, spanContext trace.SpanContext, enableB3 bool) {
+	if !spanContext.IsValid() {
+		return
+	}
+
+	header.Set(string(TraceParentHeaderKey), formatTraceParent(spanContext))
+	if traceState := spanContext.TraceState().String(); traceState != "" {
+		header.Set(string(TraceStateHeaderKey), traceState)
+	}
+	if enableB3 {
+		header.Set(string(B3HeaderKey), formatB3(spanContext))
+	}
+}
+
+// parseTraceParent parses W3C traceparent header: version-traceid-spanid-flags
+func parseTraceParent(value string) (trace.SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[3]) != 2 {
+		return trace.SpanContext{}, false
+	}
+	version, err := hex.DecodeString(parts[0])
+	if err != nil || version[0] == 0xff || (version[0] == 0 && len(parts) != 4) {
+		return trace.SpanContext{}, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	return newRemoteSpanContext(parts[1], parts[2], trace.TraceFlags(flags[0])&trace.FlagsSampled)
+}
+
+// parseB3 parses B3 single header, or B3 multiple headers if single header is absent
+func parseB3(header http.Header) (trace.SpanContext, bool) {
+	if value := header.Get(string(B3HeaderKey)); value != "" {
+		// traceid-spanid-sampled-parentspanid
+		parts := strings.Split(value, "-")
+		if len(parts) < 2 {
+			return trace.SpanContext{}, false
+		}
+		var sampled string
+		if len(parts) > 2 {
+			sampled = parts[2]
+		}
+		return newRemoteSpanContext(parts[0], parts[1], b3TraceFlags(sampled, ""))
+	}
+
+	return newRemoteSpanContext(
+		header.Get(string(B3TraceIDHeaderKey)),
+		header.Get(string(B3SpanIDHeaderKey)),
+		b3TraceFlags(header.Get(string(B3SampledHeaderKey)), header.Get(string(B3FlagsHeaderKey))),
+	)
+}
+
+func b3TraceFlags(sampled, flags string) trace.TraceFlags {
+	if flags == "1" {
+		return trace.FlagsSampled
+	}
+	switch strings.ToLower(sampled) {
+	case "1", "d", "true":
+		return trace.FlagsSampled
+	}
+
+	return 0
+}
+
+func newRemoteSpanContext(traceIDHex, spanIDHex string, flags trace.TraceFlags) (trace.SpanContext, bool) {
+	// 64 bits B3 trace id
+	if len(traceIDHex) == 16 {
+		traceIDHex = strings.Repeat("0", 16) + traceIDHex
+	}
+	traceID, err := trace.TraceIDFromHex(traceIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := trace.SpanIDFromHex(spanIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	})
+
+	return spanContext, spanContext.IsValid()
+}
+
+func formatTraceParent(spanContext trace.SpanContext) string {
+	return fmt.Sprintf("00-%s-%s-%s", spanContext.TraceID(), spanContext.SpanID(), spanContext.TraceFlags())
+}
+
+func formatB3(spanContext trace.SpanContext) string {
+	sampled := "0"
+	if spanContext.IsSampled() {
+		sampled = "1"
+	}
+
+	return fmt.Sprintf("%s-%s-%s", spanContext.TraceID(), spanContext.SpanID(), sampled)
+}
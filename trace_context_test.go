@@ -0,0 +1,182 @@
+package logadapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func assertSpanContext(t *testing.T, spanContext trace.SpanContext, ok bool, want string, wantSampled bool) {
+	t.Helper()
+
+	if ok != (want != "") {
+		t.Fatalf("ok = %v, want %v", ok, want != "")
+	}
+	if !ok {
+		return
+	}
+	if got := spanContext.TraceID().String() + "-" + spanContext.SpanID().String(); got != want {
+		t.Errorf("span context = %s, want %s", got, want)
+	}
+	if spanContext.IsSampled() != wantSampled {
+		t.Errorf("sampled = %v, want %v", spanContext.IsSampled(), wantSampled)
+	}
+	if !spanContext.IsRemote() {
+		t.Error("span context is not remote")
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		want      string // trace id-span id, empty if invalid
+		isSampled bool
+	}{
+		{name: "sampled", value: "00-" + testTraceID + "-" + testSpanID + "-01", want: testTraceID + "-" + testSpanID, isSampled: true},
+		{name: "not sampled", value: "00-" + testTraceID + "-" + testSpanID + "-00", want: testTraceID + "-" + testSpanID},
+		{name: "unknown flags", value: "00-" + testTraceID + "-" + testSpanID + "-03", want: testTraceID + "-" + testSpanID, isSampled: true},
+		{name: "spaces", value: " 00-" + testTraceID + "-" + testSpanID + "-01 ", want: testTraceID + "-" + testSpanID, isSampled: true},
+		{name: "future version", value: "01-" + testTraceID + "-" + testSpanID + "-01-extra", want: testTraceID + "-" + testSpanID, isSampled: true},
+		{name: "empty", value: ""},
+		{name: "version 00 with extra part", value: "00-" + testTraceID + "-" + testSpanID + "-01-extra"},
+		{name: "invalid version", value: "ff-" + testTraceID + "-" + testSpanID + "-01"},
+		{name: "non hex version", value: "zz-" + testTraceID + "-" + testSpanID + "-01"},
+		{name: "missing part", value: "00-" + testTraceID + "-" + testSpanID},
+		{name: "short trace id", value: "00-" + testTraceID[:30] + "-" + testSpanID + "-01"},
+		{name: "short span id", value: "00-" + testTraceID + "-" + testSpanID[:14] + "-01"},
+		{name: "non hex trace id", value: "00-" + testTraceID[:31] + "g-" + testSpanID + "-01"},
+		{name: "non hex flags", value: "00-" + testTraceID + "-" + testSpanID + "-0g"},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-" + testSpanID + "-01"},
+		{name: "zero span id", value: "00-" + testTraceID + "-0000000000000000-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanContext, ok := parseTraceParent(tt.value)
+			assertSpanContext(t, spanContext, ok, tt.want, tt.isSampled)
+		})
+	}
+}
+
+func TestParseB3(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    map[string]string
+		want      string // trace id-span id, empty if invalid
+		isSampled bool
+	}{
+		{
+			name:      "single header",
+			header:    map[string]string{"b3": testTraceID + "-" + testSpanID + "-1-" + testSpanID},
+			want:      testTraceID + "-" + testSpanID,
+			isSampled: true,
+		},
+		{
+			name:   "single header without sampled",
+			header: map[string]string{"b3": testTraceID + "-" + testSpanID},
+			want:   testTraceID + "-" + testSpanID,
+		},
+		{
+			name:      "single header debug",
+			header:    map[string]string{"b3": testTraceID + "-" + testSpanID + "-d"},
+			want:      testTraceID + "-" + testSpanID,
+			isSampled: true,
+		},
+		{
+			name:      "single header 64 bits trace id",
+			header:    map[string]string{"b3": testTraceID[16:] + "-" + testSpanID + "-1"},
+			want:      "0000000000000000" + testTraceID[16:] + "-" + testSpanID,
+			isSampled: true,
+		},
+		{
+			name: "single header is preferred",
+			header: map[string]string{
+				"b3":                       testTraceID + "-" + testSpanID + "-0",
+				string(B3TraceIDHeaderKey): "a" + testTraceID[1:],
+				string(B3SpanIDHeaderKey):  testSpanID,
+				string(B3SampledHeaderKey): "1",
+			},
+			want: testTraceID + "-" + testSpanID,
+		},
+		{name: "single header sampling only", header: map[string]string{"b3": "0"}},
+		{name: "single header zero trace id", header: map[string]string{"b3": "00000000000000000000000000000000-" + testSpanID + "-1"}},
+		{name: "single header malformed span id", header: map[string]string{"b3": testTraceID + "-xyz-1"}},
+		{
+			name: "multiple headers",
+			header: map[string]string{
+				string(B3TraceIDHeaderKey): testTraceID,
+				string(B3SpanIDHeaderKey):  testSpanID,
+				string(B3SampledHeaderKey): "true",
+			},
+			want:      testTraceID + "-" + testSpanID,
+			isSampled: true,
+		},
+		{
+			name: "multiple headers debug flag",
+			header: map[string]string{
+				string(B3TraceIDHeaderKey): testTraceID,
+				string(B3SpanIDHeaderKey):  testSpanID,
+				string(B3SampledHeaderKey): "0",
+				string(B3FlagsHeaderKey):   "1",
+			},
+			want:      testTraceID + "-" + testSpanID,
+			isSampled: true,
+		},
+		{
+			name: "multiple headers not sampled",
+			header: map[string]string{
+				string(B3TraceIDHeaderKey): testTraceID,
+				string(B3SpanIDHeaderKey):  testSpanID,
+			},
+			want: testTraceID + "-" + testSpanID,
+		},
+		{name: "multiple headers missing span id", header: map[string]string{string(B3TraceIDHeaderKey): testTraceID}},
+		{
+			name: "multiple headers zero span id",
+			header: map[string]string{
+				string(B3TraceIDHeaderKey): testTraceID,
+				string(B3SpanIDHeaderKey):  "0000000000000000",
+			},
+		},
+		{name: "no header", header: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range tt.header {
+				header.Set(key, value)
+			}
+
+			spanContext, ok := parseB3(header)
+			assertSpanContext(t, spanContext, ok, tt.want, tt.isSampled)
+		})
+	}
+}
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	header := http.Header{}
+	header.Set(string(TraceParentHeaderKey), "00-"+testTraceID+"-"+testSpanID+"-01")
+	header.Set(string(TraceStateHeaderKey), "vendor=value")
+
+	_, spanContext := extractTraceContext(context.Background(), header, true)
+	response := http.Header{}
+	injectTraceContext(response, spanContext, true)
+
+	want := map[HeaderKey]string{
+		TraceParentHeaderKey: "00-" + testTraceID + "-" + testSpanID + "-01",
+		TraceStateHeaderKey:  "vendor=value",
+		B3HeaderKey:          testTraceID + "-" + testSpanID + "-1",
+	}
+	for key, value := range want {
+		if got := response.Get(string(key)); got != value {
+			t.Errorf("header %s = %q, want %q", key, got, value)
+		}
+	}
+}
@@ -4,34 +4,46 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
-	"time"
+	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// getLogFile get file log
-func getLogFile() string {
-	path := ""
+// defaultLogDir folder of default log file config
+const defaultLogDir = "logs"
+
+// getLogDir get logs folder in working directory, create it if not exists
+func getLogDir() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
-		panic(err)
+		return "", fmt.Errorf("get working directory: %w", err)
 	}
-	path = dir + "/logs"
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		err := os.Mkdir(path, os.ModeDir)
-		if err != nil {
-			panic(err)
-		}
+	path := filepath.Join(dir, defaultLogDir)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return "", err
 	}
-	if strings.Contains(runtime.GOOS, "window") {
-		path += "\\"
-	} else {
-		path += "/"
+
+	return path, nil
+}
+
+// checkLogFile create folder of log file if not exists and check log file is writable
+func checkLogFile(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
 	}
-	return path + fmt.Sprintf("log_%s.log", time.Now().Format("2006-01-02"))
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
 }
 
 func sourceDir() {
@@ -45,6 +57,49 @@ func sourceDir() {
 	baseSourceDir = filepath.ToSlash(s) + "/"
 }
 
+// newFormatter returns formatter of log format, entries of context marked by withoutTimestamp have no timestamp
+func newFormatter(logFormat LogFormat, timestampFormat string) log.Formatter {
+	return timeFormat{
+		formatter:       newLogrusFormatter(logFormat, timestampFormat, false),
+		noTimeFormatter: newLogrusFormatter(logFormat, timestampFormat, true),
+	}
+}
+
+func newLogrusFormatter(logFormat LogFormat, timestampFormat string, isDisableTimestamp bool) log.Formatter {
+	switch logFormat {
+	case JSONFormat:
+		return &log.JSONFormatter{TimestampFormat: timestampFormat, DisableTimestamp: isDisableTimestamp}
+
+	case PrettyJSONFormat:
+		return &log.JSONFormatter{PrettyPrint: true, TimestampFormat: timestampFormat, DisableTimestamp: isDisableTimestamp}
+
+	default:
+		return &log.TextFormatter{TimestampFormat: timestampFormat, DisableTimestamp: isDisableTimestamp}
+	}
+}
+
+// noTimestampKey context key of entries are formatted without timestamp
+type noTimestampKey struct{}
+
+// withoutTimestamp returns context, entries logged with this context are formatted without timestamp
+func withoutTimestamp(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noTimestampKey{}, true)
+}
+
+// timeFormat format entry without timestamp if its context is marked by withoutTimestamp
+type timeFormat struct {
+	formatter       log.Formatter
+	noTimeFormatter log.Formatter
+}
+
+func (tf timeFormat) Format(entry *log.Entry) ([]byte, error) {
+	if entry.Context != nil && entry.Context.Value(noTimestampKey{}) != nil {
+		return tf.noTimeFormatter.Format(entry)
+	}
+
+	return tf.formatter.Format(entry)
+}
+
 // setContextKeyValue sets key value to context
 func setContextKeyValue(parent context.Context, key, value interface{}) context.Context {
 	return context.WithValue(parent, fmt.Sprintf("%s%s", DefaultPrefix, key), value)
@@ -55,6 +110,30 @@ func getContextKeyValue(ctx context.Context, key interface{}) interface{} {
 	return ctx.Value(fmt.Sprintf("%s%s", DefaultPrefix, key))
 }
 
+// contextFieldsKey context key of fields set by WithFields
+type contextFieldsKey struct{}
+
+// getContextFields gets fields set by WithFields, must not be modified
+func getContextFields(ctx context.Context) map[string]interface{} {
+	fields, _ := ctx.Value(contextFieldsKey{}).(map[string]interface{})
+
+	return fields
+}
+
+// getTraceLogFields gets trace id, span id and trace flags of OpenTelemetry span from context
+func getTraceLogFields(ctx context.Context) map[string]interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return map[string]interface{}{
+		string(TraceIDLogKey):    spanContext.TraceID().String(),
+		string(SpanIDLogKey):     spanContext.SpanID().String(),
+		string(TraceFlagsLogKey): spanContext.TraceFlags().String(),
+	}
+}
+
 // generateCorrelationID generate correlation ID by snowflake and return string
 func generateCorrelationID() string {
 	// Create a new Node with a Node number of 1
@@ -76,6 +155,92 @@ func mergeLogFields(maps ...map[string]interface{}) map[string]interface{} {
 	return merged
 }
 
+// cloneLogFields copy log fields, nested fields are copied too
+func cloneLogFields(fields map[string]interface{}) map[string]interface{} {
+	cloned := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if nested, ok := v.(map[string]interface{}); ok {
+			v = cloneLogFields(nested)
+		}
+		cloned[k] = v
+	}
+
+	return cloned
+}
+
+// matchRoute check route matches glob pattern, pattern ends with "/**" matches all sub routes
+func matchRoute(pattern, route string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return route == prefix || strings.HasPrefix(route, prefix+"/")
+	}
+	matched, _ := path.Match(pattern, route)
+
+	return matched
+}
+
+// matchAnyRoute check route matches any of glob patterns
+func matchAnyRoute(patterns []string, route string) bool {
+	for _, pattern := range patterns {
+		if matchRoute(pattern, route) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logKeySet log keys are logged from context, slice is copied on write and read without lock.
+// Zero value is ready to use with DefaultLogKeys
+type logKeySet struct {
+	mu   sync.Mutex // serializes writers
+	keys atomic.Pointer[[]LogKey]
+}
+
+// get returns current log keys, DefaultLogKeys if keys are not set. Must not be modified
+func (s *logKeySet) get() []LogKey {
+	if keys := s.keys.Load(); keys != nil {
+		return *keys
+	}
+
+	return DefaultLogKeys
+}
+
+func (s *logKeySet) set(keys []LogKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys = append([]LogKey(nil), keys...)
+	s.keys.Store(&keys)
+}
+
+func (s *logKeySet) add(key LogKey) {
+	if logKeyExists(s.get(), key) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.get()
+	if !logKeyExists(current, key) {
+		keys := append(current[:len(current):len(current)], key)
+		s.keys.Store(&keys)
+	}
+}
+
+func (s *logKeySet) remove(key LogKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.get()
+	keys := make([]LogKey, 0, len(current))
+	for _, k := range current {
+		if !strings.EqualFold(string(k), string(key)) {
+			keys = append(keys, k)
+		}
+	}
+	s.keys.Store(&keys)
+}
+
 func logKeyExists(arr []LogKey, key LogKey) bool {
 	for _, s := range arr {
 		if s == key {
@@ -0,0 +1,149 @@
+package logadapter
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// zapSourcePath is skipped in stack trace field, it's the zap package itself
+	zapSourcePath      = "go.uber.org/zap"
+	zapContextFieldKey = "logadapter_context"
+)
+
+// ZapCore implements zapcore.Core, write zap entry through Logger
+type ZapCore struct {
+	logger *Logger
+	fields map[string]interface{}
+	ctx    context.Context
+}
+
+// NewZapCore returns zap core backed by singleton logger
+func NewZapCore() *ZapCore {
+	return NewZapCoreWithLogger(GetLogger())
+}
+
+// NewZapCoreWithLogger returns zap core backed by logger
+func NewZapCoreWithLogger(logger *Logger) *ZapCore {
+	return &ZapCore{
+		logger: logger,
+		fields: make(map[string]interface{}),
+	}
+}
+
+// NewZapLogger returns zap logger backed by singleton logger
+func NewZapLogger() *zap.Logger {
+	return zap.New(NewZapCore())
+}
+
+// ZapContext returns zap field of context, log fields of context are logged with zap entry
+func ZapContext(ctx context.Context) zap.Field {
+	return zap.Field{Key: zapContextFieldKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// Enabled reports whether the core handles entries at the given level
+func (c *ZapCore) Enabled(level zapcore.Level) bool {
+	return c.logger.IsLevelEnabled(logrus.Level(fromZapLevel(level)))
+}
+
+// With returns a new core with fields
+func (c *ZapCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+	core := &ZapCore{
+		logger: c.logger,
+		fields: cloneLogFields(c.fields),
+		ctx:    c.ctx,
+	}
+	core.ctx = setZapFields(core.fields, fields, core.ctx)
+
+	return core
+}
+
+// Check adds core to checked entry if level is enabled
+func (c *ZapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+// Write log a zap entry with log fields from context
+func (c *ZapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	logFields := cloneLogFields(c.fields)
+	ctx := setZapFields(logFields, fields, c.ctx)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logFields = mergeLogFields(c.logger.GetLogFieldFromContext(ctx), logFields)
+	if entry.LoggerName != "" {
+		logFields["logger"] = entry.LoggerName
+	}
+
+	level := fromZapLevel(entry.Level)
+	if level <= WarnLevel {
+		logFields[DefaultSourceField] = c.logger.getCaller(zapSourcePath)
+	}
+
+	logrusEntry := c.logger.WithContext(ctx).WithFields(logFields).WithTime(entry.Time)
+	if level == PanicLevel {
+		// zap panics after writing entry
+		defer func() { _ = recover() }()
+	}
+	logrusEntry.Log(logrus.Level(level), entry.Message)
+
+	return nil
+}
+
+// Sync flush buffered log entries
+func (c *ZapCore) Sync() error {
+	return c.logger.Flush(context.Background())
+}
+
+// fromZapLevel convert zap level to log level
+func fromZapLevel(level zapcore.Level) Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return DebugLevel
+	case zapcore.InfoLevel:
+		return InfoLevel
+	case zapcore.WarnLevel:
+		return WarnLevel
+	case zapcore.ErrorLevel:
+		return ErrorLevel
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return PanicLevel
+	case zapcore.FatalLevel:
+		return FatalLevel
+	}
+
+	if level < zapcore.DebugLevel {
+		return TraceLevel
+	}
+
+	return InfoLevel
+}
+
+// setZapFields encode zap fields to log fields, returns context of ZapContext field or parent context
+func setZapFields(logFields map[string]interface{}, fields []zapcore.Field, ctx context.Context) context.Context {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		if field.Type == zapcore.SkipType && field.Key == zapContextFieldKey {
+			if fieldCtx, ok := field.Interface.(context.Context); ok {
+				ctx = fieldCtx
+			}
+			continue
+		}
+		field.AddTo(encoder)
+	}
+	for key, value := range encoder.Fields {
+		logFields[key] = value
+	}
+
+	return ctx
+}
@@ -0,0 +1,111 @@
+package logadapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sirupsen/logrus"
+)
+
+// zerologSourcePath is skipped in stack trace field, it's the zerolog package itself
+const zerologSourcePath = "github.com/rs/zerolog"
+
+// ZerologWriter implements zerolog.LevelWriter, write zerolog event through Logger
+type ZerologWriter struct {
+	logger *Logger
+}
+
+// NewZerologWriter returns zerolog writer backed by singleton logger
+func NewZerologWriter() *ZerologWriter {
+	return NewZerologWriterWithLogger(GetLogger())
+}
+
+// NewZerologWriterWithLogger returns zerolog writer backed by logger
+func NewZerologWriterWithLogger(logger *Logger) *ZerologWriter {
+	return &ZerologWriter{logger: logger}
+}
+
+// NewZerologLogger returns zerolog logger backed by singleton logger,
+// log fields of context set by Ctx(ctx) are logged with event
+func NewZerologLogger() zerolog.Logger {
+	return zerolog.New(NewZerologWriter()).Hook(ZerologContextHook{})
+}
+
+// Write log zerolog event at InfoLevel
+func (w *ZerologWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel log zerolog event JSON with level
+func (w *ZerologWriter) WriteLevel(zerologLevel zerolog.Level, p []byte) (int, error) {
+	fields := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return 0, err
+	}
+
+	message, _ := fields[zerolog.MessageFieldName].(string)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.LevelFieldName)
+
+	var timestamp time.Time
+	if value, ok := fields[zerolog.TimestampFieldName].(string); ok {
+		if t, err := time.Parse(zerolog.TimeFieldFormat, value); err == nil {
+			timestamp = t
+			delete(fields, zerolog.TimestampFieldName)
+		}
+	}
+
+	level := fromZerologLevel(zerologLevel)
+	if level <= WarnLevel {
+		fields[DefaultSourceField] = w.logger.getCaller(zerologSourcePath)
+	}
+
+	entry := w.logger.WithFields(fields)
+	if !timestamp.IsZero() {
+		entry = entry.WithTime(timestamp)
+	}
+	if level == PanicLevel {
+		// zerolog panics after writing event
+		defer func() { _ = recover() }()
+	}
+	entry.Log(logrus.Level(level), message)
+
+	return len(p), nil
+}
+
+// ZerologContextHook implements zerolog.Hook, add log fields of event context
+type ZerologContextHook struct{}
+
+// Run add log fields of context set by Ctx(ctx) to event
+func (ZerologContextHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil || ctx == context.Background() {
+		return
+	}
+	e.Fields(GetLogFieldFromContext(ctx))
+}
+
+// fromZerologLevel convert zerolog level to log level
+func fromZerologLevel(level zerolog.Level) Level {
+	switch level {
+	case zerolog.TraceLevel:
+		return TraceLevel
+	case zerolog.DebugLevel:
+		return DebugLevel
+	case zerolog.WarnLevel:
+		return WarnLevel
+	case zerolog.ErrorLevel:
+		return ErrorLevel
+	case zerolog.FatalLevel:
+		return FatalLevel
+	case zerolog.PanicLevel:
+		return PanicLevel
+	}
+
+	return InfoLevel
+}